@@ -2,6 +2,8 @@ package ast
 
 import (
 	"fmt"
+	"math/big"
+	"regexp"
 	"strings"
 
 	"github.com/branislavlazic/bell/token"
@@ -10,6 +12,7 @@ import (
 type Node interface {
 	TokenLiteral() string
 	String() string
+	Pos() token.Position
 }
 
 type Expression interface {
@@ -23,6 +26,12 @@ type Program struct {
 func (p *Program) TokenLiteral() string {
 	return ""
 }
+func (p *Program) Pos() token.Position {
+	if len(p.Expressions) == 0 {
+		return token.Position{}
+	}
+	return p.Expressions[0].Pos()
+}
 func (p *Program) String() string {
 	var exprs string
 	for _, expr := range p.Expressions {
@@ -39,6 +48,9 @@ type AddExpression struct {
 func (ae *AddExpression) TokenLiteral() string {
 	return ae.Token.Literal
 }
+func (ae *AddExpression) Pos() token.Position {
+	return ae.Token.Pos
+}
 func (ae *AddExpression) String() string {
 	return fmt.Sprintf("(+ %s)", concatExprsAsString(ae.Exprs))
 }
@@ -51,6 +63,9 @@ type SubtractExpression struct {
 func (se *SubtractExpression) TokenLiteral() string {
 	return se.Token.Literal
 }
+func (se *SubtractExpression) Pos() token.Position {
+	return se.Token.Pos
+}
 func (se *SubtractExpression) String() string {
 	return fmt.Sprintf("(- %s)", concatExprsAsString(se.Exprs))
 }
@@ -63,6 +78,9 @@ type MultiplyExpression struct {
 func (me *MultiplyExpression) TokenLiteral() string {
 	return me.Token.Literal
 }
+func (me *MultiplyExpression) Pos() token.Position {
+	return me.Token.Pos
+}
 func (me *MultiplyExpression) String() string {
 	return fmt.Sprintf("(* %s)", concatExprsAsString(me.Exprs))
 }
@@ -75,6 +93,9 @@ type DivideExpression struct {
 func (de *DivideExpression) TokenLiteral() string {
 	return de.Token.Literal
 }
+func (de *DivideExpression) Pos() token.Position {
+	return de.Token.Pos
+}
 func (de *DivideExpression) String() string {
 	return fmt.Sprintf("(/ %s)", concatExprsAsString(de.Exprs))
 }
@@ -87,6 +108,9 @@ type EqualExpression struct {
 func (ee *EqualExpression) TokenLiteral() string {
 	return ee.Token.Literal
 }
+func (ee *EqualExpression) Pos() token.Position {
+	return ee.Token.Pos
+}
 func (ee *EqualExpression) String() string {
 	return fmt.Sprintf("(= %s)", concatExprsAsString(ee.Exprs))
 }
@@ -99,6 +123,9 @@ type NotEqualExpression struct {
 func (nee *NotEqualExpression) TokenLiteral() string {
 	return nee.Token.Literal
 }
+func (nee *NotEqualExpression) Pos() token.Position {
+	return nee.Token.Pos
+}
 func (nee *NotEqualExpression) String() string {
 	return fmt.Sprintf("(not= %s)", concatExprsAsString(nee.Exprs))
 }
@@ -111,6 +138,9 @@ type AndExpression struct {
 func (ae *AndExpression) TokenLiteral() string {
 	return ae.Token.Literal
 }
+func (ae *AndExpression) Pos() token.Position {
+	return ae.Token.Pos
+}
 func (ae *AndExpression) String() string {
 	return fmt.Sprintf("(and %s)", concatExprsAsString(ae.Exprs))
 }
@@ -123,6 +153,9 @@ type OrExpression struct {
 func (oe *OrExpression) TokenLiteral() string {
 	return oe.Token.Literal
 }
+func (oe *OrExpression) Pos() token.Position {
+	return oe.Token.Pos
+}
 func (oe *OrExpression) String() string {
 	return fmt.Sprintf("(or %s)", concatExprsAsString(oe.Exprs))
 }
@@ -135,6 +168,9 @@ type ModuloExpression struct {
 func (me *ModuloExpression) TokenLiteral() string {
 	return me.Token.Literal
 }
+func (me *ModuloExpression) Pos() token.Position {
+	return me.Token.Pos
+}
 func (me *ModuloExpression) String() string {
 	return fmt.Sprintf("(%% %s)", concatExprsAsString(me.Exprs))
 }
@@ -147,6 +183,9 @@ type PowExpression struct {
 func (pe *PowExpression) TokenLiteral() string {
 	return pe.Token.Literal
 }
+func (pe *PowExpression) Pos() token.Position {
+	return pe.Token.Pos
+}
 func (pe *PowExpression) String() string {
 	return fmt.Sprintf("(^ %s)", concatExprsAsString(pe.Exprs))
 }
@@ -154,15 +193,49 @@ func (pe *PowExpression) String() string {
 type IntegerLiteral struct {
 	Token token.Token
 	Value int64
+	Big   *big.Int // Set instead of Value when the value doesn't fit into int64
 }
 
 func (il *IntegerLiteral) TokenLiteral() string {
 	return il.Token.Literal
 }
+func (il *IntegerLiteral) Pos() token.Position {
+	return il.Token.Pos
+}
 func (il *IntegerLiteral) String() string {
 	return il.Token.Literal
 }
 
+type FloatLiteral struct {
+	Token token.Token
+	Value float64
+}
+
+func (fl *FloatLiteral) TokenLiteral() string {
+	return fl.Token.Literal
+}
+func (fl *FloatLiteral) Pos() token.Position {
+	return fl.Token.Pos
+}
+func (fl *FloatLiteral) String() string {
+	return fl.Token.Literal
+}
+
+type RatioLiteral struct {
+	Token token.Token
+	Value *big.Rat
+}
+
+func (rl *RatioLiteral) TokenLiteral() string {
+	return rl.Token.Literal
+}
+func (rl *RatioLiteral) Pos() token.Position {
+	return rl.Token.Pos
+}
+func (rl *RatioLiteral) String() string {
+	return rl.Token.Literal
+}
+
 type BooleanLiteral struct {
 	Token token.Token
 	Value bool
@@ -171,6 +244,9 @@ type BooleanLiteral struct {
 func (bl *BooleanLiteral) TokenLiteral() string {
 	return bl.Token.Literal
 }
+func (bl *BooleanLiteral) Pos() token.Position {
+	return bl.Token.Pos
+}
 func (bl *BooleanLiteral) String() string {
 	return bl.Token.Literal
 }
@@ -183,6 +259,9 @@ type NegativeValueExpression struct {
 func (ae *NegativeValueExpression) TokenLiteral() string {
 	return ae.Token.Literal
 }
+func (ae *NegativeValueExpression) Pos() token.Position {
+	return ae.Token.Pos
+}
 func (ae *NegativeValueExpression) String() string {
 	return fmt.Sprintf("(- %s)", ae.Expr.String())
 }
@@ -195,6 +274,9 @@ type NotExpression struct {
 func (ne *NotExpression) TokenLiteral() string {
 	return ne.Token.Literal
 }
+func (ne *NotExpression) Pos() token.Position {
+	return ne.Token.Pos
+}
 func (ne *NotExpression) String() string {
 	return fmt.Sprintf("(not %s)", ne.Expr.String())
 }
@@ -207,6 +289,9 @@ type GreaterThanExpression struct {
 func (gte *GreaterThanExpression) TokenLiteral() string {
 	return gte.Token.Literal
 }
+func (gte *GreaterThanExpression) Pos() token.Position {
+	return gte.Token.Pos
+}
 func (gte *GreaterThanExpression) String() string {
 	return fmt.Sprintf("(> %s)", concatExprsAsString(gte.Exprs))
 }
@@ -219,6 +304,9 @@ type LessThanExpression struct {
 func (lte *LessThanExpression) TokenLiteral() string {
 	return lte.Token.Literal
 }
+func (lte *LessThanExpression) Pos() token.Position {
+	return lte.Token.Pos
+}
 func (lte *LessThanExpression) String() string {
 	return fmt.Sprintf("(< %s)", concatExprsAsString(lte.Exprs))
 }
@@ -231,6 +319,9 @@ type GreaterThanEqualExpression struct {
 func (gtee *GreaterThanEqualExpression) TokenLiteral() string {
 	return gtee.Token.Literal
 }
+func (gtee *GreaterThanEqualExpression) Pos() token.Position {
+	return gtee.Token.Pos
+}
 func (gtee *GreaterThanEqualExpression) String() string {
 	return fmt.Sprintf("(>= %s)", concatExprsAsString(gtee.Exprs))
 }
@@ -243,6 +334,9 @@ type LessThanEqualExpression struct {
 func (gtee *LessThanEqualExpression) TokenLiteral() string {
 	return gtee.Token.Literal
 }
+func (gtee *LessThanEqualExpression) Pos() token.Position {
+	return gtee.Token.Pos
+}
 func (gtee *LessThanEqualExpression) String() string {
 	return fmt.Sprintf("(<= %s)", concatExprsAsString(gtee.Exprs))
 }
@@ -255,6 +349,9 @@ type Identifier struct {
 func (i *Identifier) TokenLiteral() string {
 	return i.Token.Literal
 }
+func (i *Identifier) Pos() token.Position {
+	return i.Token.Pos
+}
 
 func (i *Identifier) String() string {
 	return i.Value
@@ -269,6 +366,9 @@ type LetExpression struct {
 func (le *LetExpression) TokenLiteral() string {
 	return le.Token.Literal
 }
+func (le *LetExpression) Pos() token.Position {
+	return le.Token.Pos
+}
 func (le *LetExpression) String() string {
 	return fmt.Sprintf("(let %s %s)", le.Identifier.String(), concatExprsAsString(le.Exprs))
 }
@@ -281,10 +381,48 @@ type ListExpression struct {
 func (le *ListExpression) TokenLiteral() string {
 	return le.Token.Literal
 }
+func (le *ListExpression) Pos() token.Position {
+	return le.Token.Pos
+}
 func (le *ListExpression) String() string {
 	return fmt.Sprintf("(list %s)", concatExprsAsString(le.Exprs))
 }
 
+type MapLiteral struct {
+	Token  token.Token // '{' token
+	Keys   []Expression
+	Values []Expression
+}
+
+func (ml *MapLiteral) TokenLiteral() string {
+	return ml.Token.Literal
+}
+func (ml *MapLiteral) Pos() token.Position {
+	return ml.Token.Pos
+}
+func (ml *MapLiteral) String() string {
+	var pairs []string
+	for i, key := range ml.Keys {
+		pairs = append(pairs, fmt.Sprintf("%s %s", key.String(), ml.Values[i].String()))
+	}
+	return fmt.Sprintf("{%s}", strings.Join(pairs, " "))
+}
+
+type SetLiteral struct {
+	Token    token.Token // '#{' token
+	Elements []Expression
+}
+
+func (sl *SetLiteral) TokenLiteral() string {
+	return sl.Token.Literal
+}
+func (sl *SetLiteral) Pos() token.Position {
+	return sl.Token.Pos
+}
+func (sl *SetLiteral) String() string {
+	return fmt.Sprintf("#{%s}", concatExprsAsString(sl.Elements))
+}
+
 type IfExpression struct {
 	Token     token.Token // if keyword
 	Condition Expression
@@ -295,6 +433,9 @@ type IfExpression struct {
 func (ie *IfExpression) TokenLiteral() string {
 	return ie.Token.Literal
 }
+func (ie *IfExpression) Pos() token.Position {
+	return ie.Token.Pos
+}
 func (ie *IfExpression) String() string {
 	if ie.ElseExpr != nil {
 		return fmt.Sprintf(
@@ -318,6 +459,9 @@ type Function struct {
 func (fn *Function) TokenLiteral() string {
 	return fn.Token.Literal
 }
+func (fn *Function) Pos() token.Position {
+	return fn.Token.Pos
+}
 func (fn *Function) String() string {
 	var idents []string
 	for _, ident := range fn.Params {
@@ -327,13 +471,112 @@ func (fn *Function) String() string {
 	return fmt.Sprintf("(let %s [%s] %s)", fn.Identifier.String(), identsStr, concatExprsAsString(fn.Body))
 }
 
+// FunctionLiteral is an anonymous function created with "fn".
+// Unlike Function, it doesn't bind a name in the environment.
+type FunctionLiteral struct {
+	Token  token.Token // fn keyword
+	Params []*Identifier
+	Body   []Expression
+}
+
+func (fl *FunctionLiteral) TokenLiteral() string {
+	return fl.Token.Literal
+}
+func (fl *FunctionLiteral) Pos() token.Position {
+	return fl.Token.Pos
+}
+func (fl *FunctionLiteral) String() string {
+	var idents []string
+	for _, ident := range fl.Params {
+		idents = append(idents, ident.String())
+	}
+	identsStr := strings.Join(idents, " ")
+	return fmt.Sprintf("(fn [%s] %s)", identsStr, concatExprsAsString(fl.Body))
+}
+
+type LoopExpression struct {
+	Token    token.Token // loop keyword
+	Bindings []*Identifier
+	Inits    []Expression // Initial values of bindings
+	Body     []Expression
+}
+
+func (le *LoopExpression) TokenLiteral() string {
+	return le.Token.Literal
+}
+func (le *LoopExpression) Pos() token.Position {
+	return le.Token.Pos
+}
+func (le *LoopExpression) String() string {
+	var bindings []string
+	for idx, ident := range le.Bindings {
+		bindings = append(bindings, fmt.Sprintf("%s %s", ident.String(), le.Inits[idx].String()))
+	}
+	bindingsStr := strings.Join(bindings, " ")
+	return fmt.Sprintf("(loop [%s] %s)", bindingsStr, concatExprsAsString(le.Body))
+}
+
+type RecurExpression struct {
+	Token token.Token // recur keyword
+	Exprs []Expression
+}
+
+func (re *RecurExpression) TokenLiteral() string {
+	return re.Token.Literal
+}
+func (re *RecurExpression) Pos() token.Position {
+	return re.Token.Pos
+}
+func (re *RecurExpression) String() string {
+	if len(re.Exprs) == 0 {
+		return "(recur)"
+	}
+	return fmt.Sprintf("(recur %s)", concatExprsAsString(re.Exprs))
+}
+
+type TryExpression struct {
+	Token token.Token // try keyword
+	Body  []Expression
+	Catch *CatchClause
+}
+
+func (te *TryExpression) TokenLiteral() string {
+	return te.Token.Literal
+}
+func (te *TryExpression) Pos() token.Position {
+	return te.Token.Pos
+}
+func (te *TryExpression) String() string {
+	return fmt.Sprintf("(try %s %s)", concatExprsAsString(te.Body), te.Catch.String())
+}
+
+type CatchClause struct {
+	Token      token.Token // catch keyword
+	Identifier *Identifier // Identifier bound to the caught error
+	Body       []Expression
+}
+
+func (cc *CatchClause) TokenLiteral() string {
+	return cc.Token.Literal
+}
+func (cc *CatchClause) Pos() token.Position {
+	return cc.Token.Pos
+}
+func (cc *CatchClause) String() string {
+	return fmt.Sprintf("(catch %s %s)", cc.Identifier.String(), concatExprsAsString(cc.Body))
+}
+
 type CallFunction struct {
-	Identifier *Identifier
-	Args       []Expression
+	Token  token.Token // '(' token
+	Callee Expression  // identifier or any expression evaluating to a function
+	Args   []Expression
 }
 
 func (cf *CallFunction) TokenLiteral() string {
-	return cf.Identifier.String()
+	return cf.Callee.TokenLiteral()
+}
+func (cf *CallFunction) Pos() token.Position {
+	return cf.Token.Pos
 }
 func (cf *CallFunction) String() string {
 	var args []string
@@ -341,7 +584,7 @@ func (cf *CallFunction) String() string {
 		args = append(args, arg.String())
 	}
 	argsStr := strings.Join(args, " ")
-	return fmt.Sprintf("(%s %s)", cf.Identifier.String(), argsStr)
+	return fmt.Sprintf("(%s %s)", cf.Callee.String(), argsStr)
 }
 
 type OpenExpression struct {
@@ -352,6 +595,9 @@ type OpenExpression struct {
 func (oe *OpenExpression) TokenLiteral() string {
 	return oe.Token.Literal
 }
+func (oe *OpenExpression) Pos() token.Position {
+	return oe.Token.Pos
+}
 func (oe *OpenExpression) String() string {
 	return fmt.Sprintf("(open %s)", oe.Expr)
 }
@@ -363,10 +609,45 @@ type NilExpression struct {
 func (ne *NilExpression) TokenLiteral() string {
 	return ne.Token.Literal
 }
+func (ne *NilExpression) Pos() token.Position {
+	return ne.Token.Pos
+}
 func (ne *NilExpression) String() string {
 	return ne.Token.Literal
 }
 
+type KeywordLiteral struct {
+	Token token.Token // keyword token
+	Value string      // name without the leading colon
+}
+
+func (kl *KeywordLiteral) TokenLiteral() string {
+	return kl.Token.Literal
+}
+func (kl *KeywordLiteral) Pos() token.Position {
+	return kl.Token.Pos
+}
+func (kl *KeywordLiteral) String() string {
+	return ":" + kl.Value
+}
+
+// RegexLiteral is a regular expression, e.g. #"\d+".
+// It's compiled while parsing.
+type RegexLiteral struct {
+	Token token.Token // regex token
+	Value *regexp.Regexp
+}
+
+func (rl *RegexLiteral) TokenLiteral() string {
+	return rl.Token.Literal
+}
+func (rl *RegexLiteral) Pos() token.Position {
+	return rl.Token.Pos
+}
+func (rl *RegexLiteral) String() string {
+	return "#\"" + rl.Token.Literal + "\""
+}
+
 type StringLiteral struct {
 	Token token.Token // string token
 	Value string
@@ -375,6 +656,9 @@ type StringLiteral struct {
 func (sl *StringLiteral) TokenLiteral() string {
 	return sl.Token.Literal
 }
+func (sl *StringLiteral) Pos() token.Position {
+	return sl.Token.Pos
+}
 func (sl *StringLiteral) String() string {
 	return sl.Value
 }
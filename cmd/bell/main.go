@@ -7,10 +7,12 @@ import (
 	"os"
 	"strings"
 
+	"github.com/branislavlazic/bell/diagnostic"
 	"github.com/branislavlazic/bell/evaluator"
 	"github.com/branislavlazic/bell/lexer"
 	"github.com/branislavlazic/bell/object"
 	"github.com/branislavlazic/bell/parser"
+	te "github.com/muesli/termenv"
 )
 
 func loadBellFile(fileName string) ([]byte, error) {
@@ -29,14 +31,20 @@ func main() {
 		log.Fatalf("Cannot read a file.")
 	}
 	env := object.NewEnvironment()
-	l := lexer.New(string(file))
+	renderer := diagnostic.NewRenderer(te.ColorProfile() != te.Ascii)
+	renderer.AddSource(os.Args[1], string(file))
+	l := lexer.NewWithFileName(os.Args[1], string(file))
 	p := parser.New(l)
 	program := p.ParseProgram()
 	if len(p.Errors) > 0 {
 		for _, err := range p.Errors {
-			fmt.Println(err)
+			fmt.Fprintln(os.Stderr, renderer.Render(err))
 		}
-	} else {
-		evaluator.Eval(program, env)
+		os.Exit(1)
+	}
+	result := evaluator.Eval(program, env)
+	if runtimeErr, ok := result.(*object.RuntimeError); ok {
+		fmt.Fprintln(os.Stderr, renderer.RenderRuntimeError(runtimeErr))
+		os.Exit(1)
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/branislavlazic/bell/diagnostic"
 	"github.com/branislavlazic/bell/evaluator"
 	"github.com/branislavlazic/bell/lexer"
 	"github.com/branislavlazic/bell/object"
@@ -54,16 +55,22 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			textInputValue := m.textInput.Value()
 			if !m.execCommand(textInputValue) {
 				m.expressions = append(m.expressions, textInputValue)
+				renderer := diagnostic.NewRenderer(te.ColorProfile() != te.Ascii)
+				renderer.AddSource("", textInputValue)
 				l := lexer.New(textInputValue)
 				p := parser.New(l)
 				program := p.ParseProgram()
 				if len(p.Errors) > 0 {
 					for _, err := range p.Errors {
-						m.result = outputEvalResult(m.result, textInputValue, err)
+						m.result = outputEvalResult(m.result, textInputValue, renderer.Render(err))
 					}
 				} else {
 					evalRes := evaluator.Eval(program, m.env)
-					m.result = outputEvalResult(m.result, textInputValue, evalRes.Inspect())
+					if runtimeErr, ok := evalRes.(*object.RuntimeError); ok {
+						m.result = outputEvalResult(m.result, textInputValue, renderer.RenderRuntimeError(runtimeErr))
+					} else {
+						m.result = outputEvalResult(m.result, textInputValue, evalRes.Repr())
+					}
 				}
 			}
 			if m.isQuit {
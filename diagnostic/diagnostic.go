@@ -0,0 +1,49 @@
+package diagnostic
+
+import (
+	"fmt"
+
+	"github.com/branislavlazic/bell/object"
+	"github.com/branislavlazic/bell/token"
+)
+
+// Diagnostic is an error found in a source code
+// together with the span of the code which caused it.
+type Diagnostic struct {
+	Pos     token.Position
+	Length  int // Length of the span in characters
+	Message string
+	Hint    string // Optional advice on how to fix the error
+}
+
+// New creates a diagnostic spanning the token.
+func New(tok token.Token, message string) Diagnostic {
+	length := len([]rune(tok.Literal))
+	switch tok.Type {
+	case token.STRING:
+		// The literal doesn't contain the quotes
+		length += 2
+	case token.REGEX:
+		length += 3
+	}
+	return Diagnostic{Pos: tok.Pos, Length: length, Message: message}
+}
+
+// FromRuntimeError creates a diagnostic pointing to
+// the expression which caused the runtime error.
+func FromRuntimeError(err *object.RuntimeError) Diagnostic {
+	return Diagnostic{Pos: err.Pos, Length: 1, Message: err.Error}
+}
+
+// WithHint gives a copy of the diagnostic with the hint.
+func (d Diagnostic) WithHint(hint string) Diagnostic {
+	d.Hint = hint
+	return d
+}
+
+func (d Diagnostic) String() string {
+	if !d.Pos.IsValid() {
+		return d.Message
+	}
+	return fmt.Sprintf("%s: %s", d.Pos, d.Message)
+}
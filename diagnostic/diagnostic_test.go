@@ -0,0 +1,97 @@
+package diagnostic
+
+import (
+	"testing"
+
+	"github.com/branislavlazic/bell/object"
+	"github.com/branislavlazic/bell/token"
+)
+
+func TestDiagnostic_String(t *testing.T) {
+	tests := []struct {
+		diagnostic Diagnostic
+		expected   string
+	}{
+		{Diagnostic{Pos: token.Position{Line: 1, Column: 7}, Message: "Unexpected EOF."}, "1:7: Unexpected EOF."},
+		{Diagnostic{Pos: token.Position{File: "main.bell", Line: 2, Column: 1}, Message: "Unexpected EOF."}, "main.bell:2:1: Unexpected EOF."},
+		{Diagnostic{Message: "No expression given."}, "No expression given."},
+	}
+	for i, tt := range tests {
+		if tt.diagnostic.String() != tt.expected {
+			t.Fatalf("tests[%d] - wrong string. expected=%s, got=%s", i, tt.expected, tt.diagnostic.String())
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		tok            token.Token
+		expectedLength int
+	}{
+		{token.Token{Type: token.IDENT, Literal: "size"}, 4},
+		{token.Token{Type: token.STRING, Literal: "žut"}, 5},
+		{token.Token{Type: token.REGEX, Literal: `\d+`}, 6},
+		{token.Token{Type: token.EOF, Literal: ""}, 0},
+	}
+	for i, tt := range tests {
+		d := New(tt.tok, "Error.")
+		if d.Length != tt.expectedLength {
+			t.Fatalf("tests[%d] - wrong length. expected=%d, got=%d", i, tt.expectedLength, d.Length)
+		}
+	}
+}
+
+func TestRenderer_Render(t *testing.T) {
+	source := "(let x 3)\n\t(+ x \"žut\" 4)"
+	r := NewRenderer(false)
+	r.AddSource("main.bell", source)
+	d := Diagnostic{
+		Pos:     token.Position{File: "main.bell", Line: 2, Column: 7},
+		Length:  5,
+		Message: "Cannot add a string.",
+		Hint:    "Use a number.",
+	}
+	expected := "error: Cannot add a string.\n" +
+		" --> main.bell:2:7\n" +
+		"  |\n" +
+		"2 | \t(+ x \"žut\" 4)\n" +
+		"  | \t     ^~~~~\n" +
+		"  = hint: Use a number."
+	if r.Render(d) != expected {
+		t.Fatalf("test - wrong rendering. expected=\n%s\ngot=\n%s", expected, r.Render(d))
+	}
+}
+
+func TestRenderer_RenderWithoutSource(t *testing.T) {
+	r := NewRenderer(false)
+	d := Diagnostic{Pos: token.Position{Line: 1, Column: 2}, Length: 1, Message: "Unexpected EOF."}
+	expected := "error: Unexpected EOF.\n --> 1:2"
+	if r.Render(d) != expected {
+		t.Fatalf("test - wrong rendering. expected=\n%s\ngot=\n%s", expected, r.Render(d))
+	}
+	d = Diagnostic{Message: "No expression given."}
+	expected = "error: No expression given."
+	if r.Render(d) != expected {
+		t.Fatalf("test - wrong rendering. expected=\n%s\ngot=\n%s", expected, r.Render(d))
+	}
+}
+
+func TestRenderer_RenderRuntimeError(t *testing.T) {
+	r := NewRenderer(false)
+	r.AddSource("", "(let f [] (head 1))\n(f)")
+	err := &object.RuntimeError{
+		Error:  "Function is not applicable for INTEGER type.",
+		Pos:    token.Position{Line: 1, Column: 11},
+		Frames: []object.Frame{{Function: "f", CallPos: token.Position{Line: 2, Column: 1}}},
+	}
+	expected := "error: Function is not applicable for INTEGER type.\n" +
+		" --> 1:11\n" +
+		"  |\n" +
+		"1 | (let f [] (head 1))\n" +
+		"  |           ^\n" +
+		"    at f (1:11)\n" +
+		"    at 2:1"
+	if r.RenderRuntimeError(err) != expected {
+		t.Fatalf("test - wrong rendering. expected=\n%s\ngot=\n%s", expected, r.RenderRuntimeError(err))
+	}
+}
@@ -0,0 +1,130 @@
+package diagnostic
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/branislavlazic/bell/object"
+	te "github.com/muesli/termenv"
+)
+
+const errorColor = "1"
+const gutterColor = "4"
+const hintColor = "6"
+
+// Renderer renders diagnostics along with the line of
+// the source code they point to, for example:
+//
+//	error: Illegal use of operator '+'.
+//	 --> main.bell:1:8
+//	  |
+//	1 | (+ 3 4 +)
+//	  |        ^
+//	  = hint: '+' can only be used at the start of an expression, e.g. (+ ...).
+type Renderer struct {
+	Color   bool
+	sources map[string][]string
+}
+
+func NewRenderer(color bool) *Renderer {
+	return &Renderer{Color: color, sources: map[string][]string{}}
+}
+
+// AddSource registers the source code of a file. Sources of files
+// which aren't registered are read from the disk when needed.
+// Source code which doesn't come from a file has an empty file name.
+func (r *Renderer) AddSource(file string, source string) {
+	r.sources[file] = strings.Split(source, "\n")
+}
+
+func (r *Renderer) Render(d Diagnostic) string {
+	var sb strings.Builder
+	sb.WriteString(r.style(errorColor, "error:", true))
+	sb.WriteString(" " + r.style("", d.Message, true))
+	if !d.Pos.IsValid() {
+		return sb.String()
+	}
+	gutter := strings.Repeat(" ", len(strconv.Itoa(d.Pos.Line)))
+	sb.WriteString(fmt.Sprintf("\n%s%s %s", gutter, r.style(gutterColor, "-->", false), d.Pos))
+	if line, ok := r.line(d.Pos.File, d.Pos.Line); ok {
+		sb.WriteString(fmt.Sprintf("\n%s %s", gutter, r.style(gutterColor, "|", false)))
+		sb.WriteString(fmt.Sprintf("\n%s %s", r.style(gutterColor, strconv.Itoa(d.Pos.Line), false), r.style(gutterColor, "|", false)))
+		if line != "" {
+			sb.WriteString(" " + line)
+		}
+		sb.WriteString(fmt.Sprintf("\n%s %s %s", gutter, r.style(gutterColor, "|", false), r.underline(line, d.Pos.Column, d.Length)))
+	}
+	if d.Hint != "" {
+		sb.WriteString(fmt.Sprintf("\n%s %s", gutter, r.style(hintColor, "= hint: "+d.Hint, false)))
+	}
+	return sb.String()
+}
+
+// RenderRuntimeError renders the runtime error pointing to the expression
+// which caused it, followed by the functions it passed through.
+func (r *Renderer) RenderRuntimeError(err *object.RuntimeError) string {
+	var sb strings.Builder
+	sb.WriteString(r.Render(FromRuntimeError(err)))
+	if len(err.Frames) > 0 {
+		for _, line := range err.Trace() {
+			sb.WriteString("\n    " + line)
+		}
+	}
+	return sb.String()
+}
+
+// Get a line of the source code. Lines start from 1.
+func (r *Renderer) line(file string, number int) (string, bool) {
+	lines, ok := r.sources[file]
+	if !ok && file != "" {
+		source, err := ioutil.ReadFile(file)
+		if err != nil {
+			return "", false
+		}
+		r.AddSource(file, string(source))
+		lines = r.sources[file]
+		ok = true
+	}
+	if !ok || number > len(lines) {
+		return "", false
+	}
+	return strings.TrimRight(lines[number-1], "\r"), true
+}
+
+// Create a marker under the span of the line. Tabs in front of
+// the span are kept, so that the marker stays aligned with it.
+func (r *Renderer) underline(line string, column int, length int) string {
+	var sb strings.Builder
+	for idx, ch := range []rune(line) {
+		if idx >= column-1 {
+			break
+		}
+		if ch == '\t' {
+			sb.WriteRune('\t')
+		} else {
+			sb.WriteRune(' ')
+		}
+	}
+	if length < 1 {
+		length = 1
+	}
+	marker := "^" + strings.Repeat("~", length-1)
+	sb.WriteString(r.style(errorColor, marker, true))
+	return sb.String()
+}
+
+func (r *Renderer) style(color string, s string, bold bool) string {
+	if !r.Color {
+		return s
+	}
+	style := te.String(s)
+	if color != "" {
+		style = style.Foreground(te.ANSI.Color(color))
+	}
+	if bold {
+		style = style.Bold()
+	}
+	return style.String()
+}
@@ -2,6 +2,11 @@ package evaluator
 
 import (
 	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/branislavlazic/bell/object"
 )
@@ -12,12 +17,18 @@ var builtins = map[string]*object.Builtin{
 			lenArgs := len(args)
 			if lenArgs != 1 {
 				return &object.RuntimeError{
+					Kind:  object.ArityErrorKind,
 					Error: fmt.Sprintf("Insufficient number of arguments. Expected %d, got %d.", 1, lenArgs),
 				}
 			}
 			switch arg := args[0].(type) {
 			case *object.List:
+				if len(arg.Objects) == 0 {
+					return &object.Nil{}
+				}
 				return arg.Objects[0]
+			case *object.Nil:
+				return arg
 			case *object.String:
 				arr := []rune(arg.Value)
 				if len(arr) == 0 {
@@ -25,7 +36,10 @@ var builtins = map[string]*object.Builtin{
 				}
 				return &object.String{Value: string(arr[0])}
 			default:
-				return &object.RuntimeError{Error: fmt.Sprintf("Function is not applicable for %s type.", arg.Type())}
+				return &object.RuntimeError{
+					Kind:  object.TypeErrorKind,
+					Error: fmt.Sprintf("Function is not applicable for %s type.", arg.Type()),
+				}
 			}
 		},
 	},
@@ -34,16 +48,18 @@ var builtins = map[string]*object.Builtin{
 			lenArgs := len(args)
 			if lenArgs != 1 {
 				return &object.RuntimeError{
+					Kind:  object.ArityErrorKind,
 					Error: fmt.Sprintf("Insufficient number of arguments. Expected %d, got %d.", 1, lenArgs),
 				}
 			}
 			switch arg := args[0].(type) {
 			case *object.List:
-				objects := arg.Objects[1:len(arg.Objects)]
-				if len(objects) == 0 {
+				if len(arg.Objects) == 0 {
 					return &object.Nil{}
 				}
-				return &object.List{Objects: objects}
+				return newList(arg.Objects[1:])
+			case *object.Nil:
+				return arg
 			case *object.String:
 				arr := []rune(arg.Value)
 				if len(arr) == 0 {
@@ -51,7 +67,10 @@ var builtins = map[string]*object.Builtin{
 				}
 				return &object.String{Value: string(arr[1:])}
 			default:
-				return &object.RuntimeError{Error: fmt.Sprintf("Function is not applicable for %s type.", arg.Type())}
+				return &object.RuntimeError{
+					Kind:  object.TypeErrorKind,
+					Error: fmt.Sprintf("Function is not applicable for %s type.", arg.Type()),
+				}
 			}
 		},
 	},
@@ -60,6 +79,7 @@ var builtins = map[string]*object.Builtin{
 			lenArgs := len(args)
 			if lenArgs != 1 {
 				return &object.RuntimeError{
+					Kind:  object.ArityErrorKind,
 					Error: fmt.Sprintf("Insufficient number of arguments. Expected %d, got %d.", 1, lenArgs),
 				}
 			}
@@ -67,9 +87,16 @@ var builtins = map[string]*object.Builtin{
 			case *object.List:
 				return &object.Integer{Value: int64(len(arg.Objects))}
 			case *object.String:
-				return &object.Integer{Value: int64(len(arg.Value))}
+				return &object.Integer{Value: int64(utf8.RuneCountInString(arg.Value))}
+			case *object.Map:
+				return &object.Integer{Value: int64(len(arg.Keys))}
+			case *object.Set:
+				return &object.Integer{Value: int64(len(arg.Keys))}
 			default:
-				return &object.RuntimeError{Error: fmt.Sprintf("Function is not applicable for %s type.", arg.Type())}
+				return &object.RuntimeError{
+					Kind:  object.TypeErrorKind,
+					Error: fmt.Sprintf("Function is not applicable for %s type.", arg.Type()),
+				}
 			}
 		},
 	},
@@ -89,4 +116,720 @@ var builtins = map[string]*object.Builtin{
 			return &object.Nil{}
 		},
 	},
+	"repr": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgsCount(args, 1); err != nil {
+				return err
+			}
+			return &object.String{Value: args[0].Repr()}
+		},
+	},
+	"throw": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgsCount(args, 1); err != nil {
+				return err
+			}
+			switch arg := args[0].(type) {
+			// Rethrow a caught error
+			case *object.Error:
+				return &object.RuntimeError{Kind: arg.Kind, Error: arg.Message, Value: arg.Value}
+			default:
+				return &object.RuntimeError{Kind: object.ThrownErrorKind, Error: arg.Inspect(), Value: arg}
+			}
+		},
+	},
+	"error-message": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgsCount(args, 1); err != nil {
+				return err
+			}
+			switch arg := args[0].(type) {
+			case *object.Error:
+				return &object.String{Value: arg.Message}
+			default:
+				return &object.RuntimeError{
+					Kind:  object.TypeErrorKind,
+					Error: fmt.Sprintf("Function is not applicable for %s type.", arg.Type()),
+				}
+			}
+		},
+	},
+	"error-kind": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgsCount(args, 1); err != nil {
+				return err
+			}
+			switch arg := args[0].(type) {
+			case *object.Error:
+				return &object.String{Value: string(arg.Kind)}
+			default:
+				return &object.RuntimeError{
+					Kind:  object.TypeErrorKind,
+					Error: fmt.Sprintf("Function is not applicable for %s type.", arg.Type()),
+				}
+			}
+		},
+	},
+	"error-value": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgsCount(args, 1); err != nil {
+				return err
+			}
+			switch arg := args[0].(type) {
+			case *object.Error:
+				// Only thrown errors carry a value
+				if arg.Value == nil {
+					return &object.Nil{}
+				}
+				return arg.Value
+			default:
+				return &object.RuntimeError{
+					Kind:  object.TypeErrorKind,
+					Error: fmt.Sprintf("Function is not applicable for %s type.", arg.Type()),
+				}
+			}
+		},
+	},
+	"int": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgsCount(args, 1); err != nil {
+				return err
+			}
+			switch arg := args[0].(type) {
+			case *object.Integer, *object.BigInteger:
+				return arg
+			case *object.Ratio:
+				return newInteger(truncRat(arg.Value))
+			case *object.Float:
+				// The fraction is discarded
+				return floatToInteger(math.Trunc(arg.Value))
+			case *object.String:
+				value, ok := new(big.Int).SetString(strings.TrimSpace(arg.Value), 10)
+				if !ok {
+					return &object.RuntimeError{
+						Kind:  object.TypeErrorKind,
+						Error: fmt.Sprintf("Cannot convert \"%s\" to INTEGER type.", arg.Value),
+					}
+				}
+				return newInteger(value)
+			default:
+				return notApplicable(arg)
+			}
+		},
+	},
+	"float": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgsCount(args, 1); err != nil {
+				return err
+			}
+			switch arg := args[0].(type) {
+			case *object.Integer, *object.BigInteger, *object.Ratio, *object.Float:
+				return &object.Float{Value: toFloat(arg)}
+			case *object.String:
+				value, err := strconv.ParseFloat(strings.TrimSpace(arg.Value), 64)
+				if err != nil {
+					return &object.RuntimeError{
+						Kind:  object.TypeErrorKind,
+						Error: fmt.Sprintf("Cannot convert \"%s\" to FLOAT type.", arg.Value),
+					}
+				}
+				return &object.Float{Value: value}
+			default:
+				return notApplicable(arg)
+			}
+		},
+	},
+	"round": {
+		Fn: func(args ...object.Object) object.Object {
+			return roundNumber(args, math.Round, roundRat)
+		},
+	},
+	"floor": {
+		Fn: func(args ...object.Object) object.Object {
+			return roundNumber(args, math.Floor, floorRat)
+		},
+	},
+	"ceil": {
+		Fn: func(args ...object.Object) object.Object {
+			return roundNumber(args, math.Ceil, ceilRat)
+		},
+	},
+	"ratio": {
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) == 0 {
+				return &object.RuntimeError{
+					Kind:  object.ArityErrorKind,
+					Error: "Insufficient number of arguments. Expected at least 1, got 0.",
+				}
+			}
+			// Exact division of all arguments
+			var result *big.Rat
+			for _, arg := range args {
+				if !isInteger(arg) && arg.Type() != object.RatioObj {
+					return notApplicable(arg)
+				}
+				if result == nil {
+					result = new(big.Rat).Set(toRat(arg))
+				} else if toRat(arg).Sign() == 0 {
+					return divisionByZeroError()
+				} else {
+					result.Quo(result, toRat(arg))
+				}
+			}
+			return newRatio(result)
+		},
+	},
+	"numerator": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgsCount(args, 1); err != nil {
+				return err
+			}
+			switch arg := args[0].(type) {
+			case *object.Integer, *object.BigInteger:
+				return arg
+			case *object.Ratio:
+				return newInteger(new(big.Int).Set(arg.Value.Num()))
+			default:
+				return notApplicable(arg)
+			}
+		},
+	},
+	"denominator": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgsCount(args, 1); err != nil {
+				return err
+			}
+			switch arg := args[0].(type) {
+			case *object.Integer, *object.BigInteger:
+				return &object.Integer{Value: 1}
+			case *object.Ratio:
+				return newInteger(new(big.Int).Set(arg.Value.Denom()))
+			default:
+				return notApplicable(arg)
+			}
+		},
+	},
+	"get": {
+		Fn: func(args ...object.Object) object.Object {
+			// The default value is optional
+			if len(args) < 2 {
+				return checkArgsCount(args, 2)
+			} else if len(args) > 3 {
+				return checkArgsCount(args, 3)
+			}
+			if len(args) == 3 {
+				return lookup(args[0], args[1], args[2])
+			}
+			return lookup(args[0], args[1], &object.Nil{})
+		},
+	},
+	"assoc": {
+		Fn: func(args ...object.Object) object.Object {
+			return updateMap(args, 3, 2, func(m *object.Map, key object.Hashable, values []object.Object) {
+				m.Set(key, values[0])
+			})
+		},
+	},
+	"dissoc": {
+		Fn: func(args ...object.Object) object.Object {
+			return updateMap(args, 2, 1, func(m *object.Map, key object.Hashable, _ []object.Object) {
+				m.Delete(key)
+			})
+		},
+	},
+	"keys": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgsCount(args, 1); err != nil {
+				return err
+			}
+			m, err := toMap(args[0])
+			if err != nil {
+				return err
+			}
+			var keys []object.Object
+			for _, hashKey := range m.Keys {
+				keys = append(keys, m.Pairs[hashKey].Key)
+			}
+			return newList(keys)
+		},
+	},
+	"vals": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgsCount(args, 1); err != nil {
+				return err
+			}
+			m, err := toMap(args[0])
+			if err != nil {
+				return err
+			}
+			var values []object.Object
+			for _, hashKey := range m.Keys {
+				values = append(values, m.Pairs[hashKey].Value)
+			}
+			return newList(values)
+		},
+	},
+	"contains?": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgsCount(args, 2); err != nil {
+				return err
+			}
+			// A string contains a substring
+			if _, ok := args[0].(*object.String); ok {
+				return testStrings(args, strings.Contains)
+			}
+			key, err := toHashable(args[1])
+			if err != nil {
+				return err
+			}
+			if s, ok := args[0].(*object.Set); ok {
+				return &object.Boolean{Value: s.Contains(key)}
+			}
+			m, err := toMap(args[0])
+			if err != nil {
+				return err
+			}
+			_, ok := m.Get(key)
+			return &object.Boolean{Value: ok}
+		},
+	},
+	"set": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgsCount(args, 1); err != nil {
+				return err
+			}
+			switch arg := args[0].(type) {
+			case *object.Set:
+				return arg
+			case *object.Nil:
+				return object.NewSet()
+			case *object.List:
+				// Duplicate elements are dropped
+				set := object.NewSet()
+				for _, obj := range arg.Objects {
+					element, err := toHashable(obj)
+					if err != nil {
+						return err
+					}
+					set.Add(element)
+				}
+				return set
+			default:
+				return notApplicable(arg)
+			}
+		},
+	},
+	"conj": {
+		Fn: func(args ...object.Object) object.Object {
+			return updateSet(args, func(s *object.Set, element object.Hashable) {
+				s.Add(element)
+			})
+		},
+	},
+	"disj": {
+		Fn: func(args ...object.Object) object.Object {
+			return updateSet(args, func(s *object.Set, element object.Hashable) {
+				s.Remove(element)
+			})
+		},
+	},
+	"union": {
+		Fn: func(args ...object.Object) object.Object {
+			return combineSets(args, func(result *object.Set, other *object.Set) *object.Set {
+				union := result.Copy()
+				for _, hashKey := range other.Keys {
+					union.Add(other.Elements[hashKey])
+				}
+				return union
+			})
+		},
+	},
+	"intersection": {
+		Fn: func(args ...object.Object) object.Object {
+			return combineSets(args, func(result *object.Set, other *object.Set) *object.Set {
+				intersection := object.NewSet()
+				for _, hashKey := range result.Keys {
+					if element := result.Elements[hashKey]; other.Contains(element) {
+						intersection.Add(element)
+					}
+				}
+				return intersection
+			})
+		},
+	},
+	"difference": {
+		Fn: func(args ...object.Object) object.Object {
+			return combineSets(args, func(result *object.Set, other *object.Set) *object.Set {
+				difference := object.NewSet()
+				for _, hashKey := range result.Keys {
+					if element := result.Elements[hashKey]; !other.Contains(element) {
+						difference.Add(element)
+					}
+				}
+				return difference
+			})
+		},
+	},
+	"cons": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgsCount(args, 2); err != nil {
+				return err
+			}
+			objects, err := toObjects(args[1])
+			if err != nil {
+				return err
+			}
+			return &object.List{Objects: append([]object.Object{args[0]}, objects...)}
+		},
+	},
+	"append": {
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 2 {
+				return &object.RuntimeError{
+					Kind:  object.ArityErrorKind,
+					Error: fmt.Sprintf("Insufficient number of arguments. Expected at least %d, got %d.", 2, len(args)),
+				}
+			}
+			objects, err := toObjects(args[0])
+			if err != nil {
+				return err
+			}
+			appended := append([]object.Object{}, objects...)
+			return &object.List{Objects: append(appended, args[1:]...)}
+		},
+	},
+	"concat": {
+		Fn: func(args ...object.Object) object.Object {
+			var concatenated []object.Object
+			for _, arg := range args {
+				objects, err := toObjects(arg)
+				if err != nil {
+					return err
+				}
+				concatenated = append(concatenated, objects...)
+			}
+			return newList(concatenated)
+		},
+	},
+	"nth": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgsCount(args, 2); err != nil {
+				return err
+			}
+			switch arg := args[0].(type) {
+			case *object.List, *object.Nil:
+				objects, _ := toObjects(arg)
+				index, err := toIndex(args[1], len(objects))
+				if err != nil {
+					return err
+				}
+				return objects[index]
+			case *object.String:
+				runes := []rune(arg.Value)
+				index, err := toIndex(args[1], len(runes))
+				if err != nil {
+					return err
+				}
+				return &object.String{Value: string(runes[index])}
+			default:
+				return notApplicable(arg)
+			}
+		},
+	},
+	"last": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgsCount(args, 1); err != nil {
+				return err
+			}
+			switch arg := args[0].(type) {
+			case *object.List, *object.Nil:
+				objects, _ := toObjects(arg)
+				if len(objects) == 0 {
+					return &object.Nil{}
+				}
+				return objects[len(objects)-1]
+			case *object.String:
+				runes := []rune(arg.Value)
+				if len(runes) == 0 {
+					return &object.String{Value: ""}
+				}
+				return &object.String{Value: string(runes[len(runes)-1])}
+			default:
+				return notApplicable(arg)
+			}
+		},
+	},
+	"reverse": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgsCount(args, 1); err != nil {
+				return err
+			}
+			switch arg := args[0].(type) {
+			case *object.List, *object.Nil:
+				objects, _ := toObjects(arg)
+				reversed := make([]object.Object, len(objects))
+				for i, obj := range objects {
+					reversed[len(objects)-1-i] = obj
+				}
+				return newList(reversed)
+			case *object.String:
+				runes := []rune(arg.Value)
+				for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+					runes[i], runes[j] = runes[j], runes[i]
+				}
+				return &object.String{Value: string(runes)}
+			default:
+				return notApplicable(arg)
+			}
+		},
+	},
+	"range": {
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) == 0 {
+				return checkArgsCount(args, 1)
+			} else if len(args) > 3 {
+				return checkArgsCount(args, 3)
+			}
+			for _, arg := range args {
+				if _, ok := arg.(*object.Integer); !ok {
+					return notApplicable(arg)
+				}
+			}
+			// Either (range end), (range start end) or (range start end step)
+			start, end, step := int64(0), args[0].(*object.Integer).Value, int64(1)
+			if len(args) > 1 {
+				start, end = end, args[1].(*object.Integer).Value
+			}
+			if len(args) > 2 {
+				step = args[2].(*object.Integer).Value
+			}
+			if step == 0 {
+				return &object.RuntimeError{Kind: object.ArithmeticErrorKind, Error: "Step of a range cannot be zero."}
+			}
+			if size := rangeSize(start, end, step); size > maxRangeSize {
+				return &object.RuntimeError{
+					Kind:  object.ArithmeticErrorKind,
+					Error: fmt.Sprintf("Range of %d values is too large. It can have at most %d values.", size, maxRangeSize),
+				}
+			}
+			var objects []object.Object
+			for i := start; (step > 0 && i < end) || (step < 0 && i > end); i += step {
+				objects = append(objects, &object.Integer{Value: i})
+				// Stop before the next value overflows
+				if (step > 0 && i > math.MaxInt64-step) || (step < 0 && i < math.MinInt64-step) {
+					break
+				}
+			}
+			return newList(objects)
+		},
+	},
+	"take": {
+		Fn: func(args ...object.Object) object.Object {
+			return splitAt(args, true)
+		},
+	},
+	"drop": {
+		Fn: func(args ...object.Object) object.Object {
+			return splitAt(args, false)
+		},
+	},
+	"empty?": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgsCount(args, 1); err != nil {
+				return err
+			}
+			switch arg := args[0].(type) {
+			case *object.Nil:
+				return &object.Boolean{Value: true}
+			case *object.List:
+				return &object.Boolean{Value: len(arg.Objects) == 0}
+			case *object.String:
+				return &object.Boolean{Value: len(arg.Value) == 0}
+			case *object.Map:
+				return &object.Boolean{Value: len(arg.Keys) == 0}
+			case *object.Set:
+				return &object.Boolean{Value: len(arg.Keys) == 0}
+			default:
+				return notApplicable(arg)
+			}
+		},
+	},
+	"flatten": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgsCount(args, 1); err != nil {
+				return err
+			}
+			objects, err := toObjects(args[0])
+			if err != nil {
+				return err
+			}
+			return newList(flatten(objects, nil))
+		},
+	},
+	"split": {
+		Fn: func(args ...object.Object) object.Object {
+			values, err := stringArgs(args, 2)
+			if err != nil {
+				return err
+			}
+			// An empty separator splits the string into characters
+			return newStringList(strings.Split(values[0], values[1]))
+		},
+	},
+	"join": {
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 1 {
+				return checkArgsCount(args, 1)
+			} else if len(args) > 2 {
+				return checkArgsCount(args, 2)
+			}
+			elements, err := toSeq(args[0])
+			if err != nil {
+				return err
+			}
+			separator := ""
+			if len(args) == 2 {
+				str, ok := args[1].(*object.String)
+				if !ok {
+					return notApplicable(args[1])
+				}
+				separator = str.Value
+			}
+			// Elements are joined in their display form
+			values := make([]string, len(elements))
+			for i, element := range elements {
+				values[i] = element.Inspect()
+			}
+			return &object.String{Value: strings.Join(values, separator)}
+		},
+	},
+	"substring": {
+		Fn: func(args ...object.Object) object.Object {
+			return substring(args)
+		},
+	},
+	"index-of": {
+		Fn: func(args ...object.Object) object.Object {
+			values, err := stringArgs(args, 2)
+			if err != nil {
+				return err
+			}
+			return &object.Integer{Value: int64(runeIndex(values[0], values[1]))}
+		},
+	},
+	"replace": {
+		Fn: func(args ...object.Object) object.Object {
+			values, err := stringArgs(args, 3)
+			if err != nil {
+				return err
+			}
+			return &object.String{Value: strings.ReplaceAll(values[0], values[1], values[2])}
+		},
+	},
+	"upper": {
+		Fn: func(args ...object.Object) object.Object {
+			return mapString(args, strings.ToUpper)
+		},
+	},
+	"lower": {
+		Fn: func(args ...object.Object) object.Object {
+			return mapString(args, strings.ToLower)
+		},
+	},
+	"trim": {
+		Fn: func(args ...object.Object) object.Object {
+			return mapString(args, strings.TrimSpace)
+		},
+	},
+	"starts-with?": {
+		Fn: func(args ...object.Object) object.Object {
+			return testStrings(args, strings.HasPrefix)
+		},
+	},
+	"ends-with?": {
+		Fn: func(args ...object.Object) object.Object {
+			return testStrings(args, strings.HasSuffix)
+		},
+	},
+	"repeat": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgsCount(args, 2); err != nil {
+				return err
+			}
+			str, ok := args[0].(*object.String)
+			if !ok {
+				return notApplicable(args[0])
+			}
+			count, ok := args[1].(*object.Integer)
+			if !ok {
+				return notApplicable(args[1])
+			}
+			if count.Value < 0 {
+				return &object.RuntimeError{
+					Kind:  object.ArithmeticErrorKind,
+					Error: fmt.Sprintf("A string cannot be repeated a negative number of times %d.", count.Value),
+				}
+			}
+			if len(str.Value) > 0 && count.Value > maxStringSize/int64(len(str.Value)) {
+				return &object.RuntimeError{
+					Kind:  object.ArithmeticErrorKind,
+					Error: fmt.Sprintf("A repeated string is too large. It can have at most %d bytes.", maxStringSize),
+				}
+			}
+			return &object.String{Value: strings.Repeat(str.Value, int(count.Value))}
+		},
+	},
+	"chars": {
+		Fn: func(args ...object.Object) object.Object {
+			values, err := stringArgs(args, 1)
+			if err != nil {
+				return err
+			}
+			return newStringList(strings.Split(values[0], ""))
+		},
+	},
+	"format": {
+		Fn: format,
+	},
+}
+
+// Check whether a builtin function got the expected number of arguments.
+func checkArgsCount(args []object.Object, expected int) *object.RuntimeError {
+	if len(args) > expected {
+		return &object.RuntimeError{
+			Kind:  object.ArityErrorKind,
+			Error: fmt.Sprintf("Too many arguments. Expected %d, got %d.", expected, len(args)),
+		}
+	}
+	if len(args) < expected {
+		return &object.RuntimeError{
+			Kind:  object.ArityErrorKind,
+			Error: fmt.Sprintf("Insufficient number of arguments. Expected %d, got %d.", expected, len(args)),
+		}
+	}
+	return nil
+}
+
+func notApplicable(arg object.Object) *object.RuntimeError {
+	return &object.RuntimeError{
+		Kind:  object.TypeErrorKind,
+		Error: fmt.Sprintf("Function is not applicable for %s type.", arg.Type()),
+	}
+}
+
+// Round a number to an integer with the given rounding functions for floats and ratios.
+func roundNumber(args []object.Object, round func(float64) float64, roundRat func(*big.Rat) *big.Int) object.Object {
+	if err := checkArgsCount(args, 1); err != nil {
+		return err
+	}
+	switch arg := args[0].(type) {
+	case *object.Integer, *object.BigInteger:
+		return arg
+	case *object.Ratio:
+		return newInteger(roundRat(arg.Value))
+	case *object.Float:
+		return floatToInteger(round(arg.Value))
+	default:
+		return notApplicable(arg)
+	}
 }
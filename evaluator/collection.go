@@ -0,0 +1,289 @@
+package evaluator
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/branislavlazic/bell/object"
+)
+
+func toHashable(obj object.Object) (object.Hashable, *object.RuntimeError) {
+	hashable, ok := obj.(object.Hashable)
+	if !ok {
+		return nil, &object.RuntimeError{
+			Kind:  object.TypeErrorKind,
+			Error: fmt.Sprintf("Value of %s type cannot be used as a map key or a set element.", obj.Type()),
+		}
+	}
+	return hashable, nil
+}
+
+// Give the map, or an empty map for nil.
+func toMap(obj object.Object) (*object.Map, *object.RuntimeError) {
+	switch obj := obj.(type) {
+	case *object.Map:
+		return obj, nil
+	case *object.Nil:
+		return object.NewMap(), nil
+	default:
+		return nil, notApplicable(obj)
+	}
+}
+
+// Give a list of the objects, or nil if there are none.
+func newList(objects []object.Object) object.Object {
+	if len(objects) == 0 {
+		return &object.Nil{}
+	}
+	return &object.List{Objects: objects}
+}
+
+// Apply a function to the map and each of the given keys.
+// A new map is returned and the original one stays unchanged.
+func updateMap(args []object.Object, minArgs int, step int, update func(m *object.Map, key object.Hashable, args []object.Object)) object.Object {
+	if len(args) < minArgs {
+		return &object.RuntimeError{
+			Kind:  object.ArityErrorKind,
+			Error: fmt.Sprintf("Insufficient number of arguments. Expected at least %d, got %d.", minArgs, len(args)),
+		}
+	}
+	if (len(args)-1)%step != 0 {
+		return &object.RuntimeError{
+			Kind:  object.ArityErrorKind,
+			Error: "Keys must be followed by values.",
+		}
+	}
+	m, err := toMap(args[0])
+	if err != nil {
+		return err
+	}
+	updated := m.Copy()
+	for i := 1; i < len(args); i += step {
+		key, err := toHashable(args[i])
+		if err != nil {
+			return err
+		}
+		update(updated, key, args[i+1:i+step])
+	}
+	return updated
+}
+
+// Look up a value of a key in a map. If the map doesn't
+// contain the key, the default value is returned.
+func lookup(m object.Object, key object.Object, defaultValue object.Object) object.Object {
+	mapObj, err := toMap(m)
+	if err != nil {
+		return err
+	}
+	hashable, err := toHashable(key)
+	if err != nil {
+		return err
+	}
+	if value, ok := mapObj.Get(hashable); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// Call a keyword with a map and an optional default
+// value, which looks the keyword up in the map.
+func lookupKeyword(keyword *object.Keyword, args []object.Object) object.Object {
+	if len(args) > 2 {
+		return checkArgsCount(args, 2)
+	}
+	if len(args) == 2 {
+		return lookup(args[0], keyword, args[1])
+	}
+	return lookup(args[0], keyword, &object.Nil{})
+}
+
+// Give the set, or an empty set for nil.
+func toSet(obj object.Object) (*object.Set, *object.RuntimeError) {
+	switch obj := obj.(type) {
+	case *object.Set:
+		return obj, nil
+	case *object.Nil:
+		return object.NewSet(), nil
+	default:
+		return nil, notApplicable(obj)
+	}
+}
+
+// Apply a function to the set and each of the given elements.
+// A new set is returned and the original one stays unchanged.
+func updateSet(args []object.Object, update func(s *object.Set, element object.Hashable)) object.Object {
+	if len(args) < 2 {
+		return &object.RuntimeError{
+			Kind:  object.ArityErrorKind,
+			Error: fmt.Sprintf("Insufficient number of arguments. Expected at least %d, got %d.", 2, len(args)),
+		}
+	}
+	s, err := toSet(args[0])
+	if err != nil {
+		return err
+	}
+	updated := s.Copy()
+	for _, arg := range args[1:] {
+		element, err := toHashable(arg)
+		if err != nil {
+			return err
+		}
+		update(updated, element)
+	}
+	return updated
+}
+
+// Combine the first set with each of the following ones. The combine
+// function gives elements of the result from the two sets.
+func combineSets(args []object.Object, combine func(result *object.Set, other *object.Set) *object.Set) object.Object {
+	if len(args) == 0 {
+		return &object.RuntimeError{
+			Kind:  object.ArityErrorKind,
+			Error: "Insufficient number of arguments. Expected at least 1, got 0.",
+		}
+	}
+	result, err := toSet(args[0])
+	if err != nil {
+		return err
+	}
+	for _, arg := range args[1:] {
+		other, err := toSet(arg)
+		if err != nil {
+			return err
+		}
+		result = combine(result, other)
+	}
+	return result
+}
+
+// Give elements of the list, or no elements for nil.
+func toObjects(obj object.Object) ([]object.Object, *object.RuntimeError) {
+	switch obj := obj.(type) {
+	case *object.List:
+		return obj.Objects, nil
+	case *object.Nil:
+		return nil, nil
+	default:
+		return nil, notApplicable(obj)
+	}
+}
+
+// Check whether the object is an index within a sequence of the size.
+func toIndex(obj object.Object, size int) (int, *object.RuntimeError) {
+	if !isInteger(obj) {
+		return 0, &object.RuntimeError{
+			Kind:  object.TypeErrorKind,
+			Error: fmt.Sprintf("Index must be of INTEGER type, got %s.", obj.Type()),
+		}
+	}
+	index, ok := obj.(*object.Integer)
+	if !ok || index.Value < 0 || index.Value >= int64(size) {
+		return 0, &object.RuntimeError{
+			Kind:  object.IndexErrorKind,
+			Error: fmt.Sprintf("Index %s is out of range for size %d.", obj.Inspect(), size),
+		}
+	}
+	return int(index.Value), nil
+}
+
+// Maximum number of values of a range, so that
+// a huge range can't exhaust the memory.
+const maxRangeSize = 1 << 22
+
+// Give the number of values of a range. It's computed with
+// big integers, since the distance can overflow int64.
+func rangeSize(start int64, end int64, step int64) uint64 {
+	distance := new(big.Int).Sub(big.NewInt(end), big.NewInt(start))
+	absStep := new(big.Int).Abs(big.NewInt(step))
+	if distance.Sign() != big.NewInt(step).Sign() {
+		return 0
+	}
+	// Round up, since the start is always included
+	size := distance.Abs(distance)
+	size.Add(size, absStep).Sub(size, big.NewInt(1))
+	return size.Quo(size, absStep).Uint64()
+}
+
+// Split a list or a string after the given number of elements, and give
+// either the first or the second part. The number is clamped to the size,
+// so that it's never out of range.
+func splitAt(args []object.Object, first bool) object.Object {
+	if err := checkArgsCount(args, 2); err != nil {
+		return err
+	}
+	if !isInteger(args[0]) {
+		return notApplicable(args[0])
+	}
+	switch arg := args[1].(type) {
+	case *object.List, *object.Nil:
+		objects, _ := toObjects(arg)
+		n := clampCount(args[0], len(objects))
+		if first {
+			return newList(objects[:n])
+		}
+		return newList(objects[n:])
+	case *object.String:
+		runes := []rune(arg.Value)
+		n := clampCount(args[0], len(runes))
+		if first {
+			return &object.String{Value: string(runes[:n])}
+		}
+		return &object.String{Value: string(runes[n:])}
+	default:
+		return notApplicable(arg)
+	}
+}
+
+func clampCount(count object.Object, size int) int {
+	if n, ok := count.(*object.Integer); ok && n.Value >= 0 && n.Value <= int64(size) {
+		return int(n.Value)
+	}
+	if toBigInt(count).Sign() < 0 {
+		return 0
+	}
+	return size
+}
+
+// Append elements of the objects to the flat list, descending into nested lists.
+func flatten(objects []object.Object, flat []object.Object) []object.Object {
+	for _, obj := range objects {
+		if list, ok := obj.(*object.List); ok {
+			flat = flatten(list.Objects, flat)
+		} else {
+			flat = append(flat, obj)
+		}
+	}
+	return flat
+}
+
+// Give elements of a collection in their order. Characters
+// of a string are strings, and pairs of a map are lists.
+func toSeq(obj object.Object) ([]object.Object, *object.RuntimeError) {
+	switch obj := obj.(type) {
+	case *object.List:
+		return obj.Objects, nil
+	case *object.Nil:
+		return nil, nil
+	case *object.Set:
+		var elements []object.Object
+		for _, hashKey := range obj.Keys {
+			elements = append(elements, obj.Elements[hashKey])
+		}
+		return elements, nil
+	case *object.Map:
+		var pairs []object.Object
+		for _, hashKey := range obj.Keys {
+			pair := obj.Pairs[hashKey]
+			pairs = append(pairs, &object.List{Objects: []object.Object{pair.Key, pair.Value}})
+		}
+		return pairs, nil
+	case *object.String:
+		var chars []object.Object
+		for _, char := range obj.Value {
+			chars = append(chars, &object.String{Value: string(char)})
+		}
+		return chars, nil
+	default:
+		return nil, notApplicable(obj)
+	}
+}
@@ -0,0 +1,102 @@
+package evaluator
+
+import (
+	"strings"
+
+	"github.com/branislavlazic/bell/object"
+)
+
+// Check whether two values are structurally equal. Numbers are equal
+// when they have the same value regardless of their types, while other
+// values of different types are never equal. Collections are equal
+// when their elements are equal, though the order of elements
+// matters only for lists. Regexes are equal when their patterns are.
+func objectsEqual(left object.Object, right object.Object) bool {
+	if isNumber(left) && isNumber(right) {
+		return compareNumbers(left, right) == 0
+	}
+	if left.Type() != right.Type() {
+		return false
+	}
+	switch left := left.(type) {
+	case *object.Boolean:
+		return left.Value == right.(*object.Boolean).Value
+	case *object.String:
+		return left.Value == right.(*object.String).Value
+	case *object.Nil:
+		return true
+	case *object.List:
+		right := right.(*object.List)
+		if len(left.Objects) != len(right.Objects) {
+			return false
+		}
+		for i, obj := range left.Objects {
+			if !objectsEqual(obj, right.Objects[i]) {
+				return false
+			}
+		}
+		return true
+	case *object.Map:
+		right := right.(*object.Map)
+		if len(left.Keys) != len(right.Keys) {
+			return false
+		}
+		for hashKey, pair := range left.Pairs {
+			otherPair, ok := right.Pairs[hashKey]
+			if !ok || !objectsEqual(pair.Value, otherPair.Value) {
+				return false
+			}
+		}
+		return true
+	case *object.Set:
+		right := right.(*object.Set)
+		if len(left.Keys) != len(right.Keys) {
+			return false
+		}
+		for hashKey := range left.Elements {
+			if _, ok := right.Elements[hashKey]; !ok {
+				return false
+			}
+		}
+		return true
+	case *object.Regex:
+		return left.Value.String() == right.(*object.Regex).Value.String()
+	case *object.Error:
+		right := right.(*object.Error)
+		return left.Kind == right.Kind && left.Message == right.Message
+	default:
+		// Keywords are interned and functions are equal only to themselves
+		return left == right
+	}
+}
+
+// Compare two values of an ordered type. Numbers are compared by their
+// values, strings and keywords lexicographically, and lists element by
+// element, where a shorter list goes before a longer one which starts
+// with the same elements. It isn't ok if the values can't be ordered.
+func compareObjects(left object.Object, right object.Object) (int, bool) {
+	if isNumber(left) && isNumber(right) {
+		return compareNumbers(left, right), true
+	}
+	switch left := left.(type) {
+	case *object.String:
+		if right, ok := right.(*object.String); ok {
+			return strings.Compare(left.Value, right.Value), true
+		}
+	case *object.Keyword:
+		if right, ok := right.(*object.Keyword); ok {
+			return strings.Compare(left.Name, right.Name), true
+		}
+	case *object.List:
+		if right, ok := right.(*object.List); ok {
+			for i := 0; i < len(left.Objects) && i < len(right.Objects); i++ {
+				cmp, ok := compareObjects(left.Objects[i], right.Objects[i])
+				if !ok || cmp != 0 {
+					return cmp, ok
+				}
+			}
+			return compareInts(int64(len(left.Objects)), int64(len(right.Objects))), true
+		}
+	}
+	return 0, false
+}
@@ -3,19 +3,40 @@ package evaluator
 import (
 	"fmt"
 	"io/ioutil"
-	"math"
 
 	"github.com/branislavlazic/bell/lexer"
 	"github.com/branislavlazic/bell/parser"
 
 	"github.com/branislavlazic/bell/ast"
 	"github.com/branislavlazic/bell/object"
+	"github.com/branislavlazic/bell/token"
 )
 
+// Eval evaluates the node. A runtime error which doesn't have
+// a position yet gets the position of the node.
 func Eval(node ast.Node, env *object.Environment) object.Object {
+	if program, ok := node.(*ast.Program); ok {
+		return evalProgram(program, env)
+	}
+	return withPos(eval(node, env), node)
+}
+
+// Evaluate the program. An unexpected panic of the evaluator
+// is turned into a runtime error instead of crashing the host.
+func evalProgram(program *ast.Program, env *object.Environment) (result object.Object) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = &object.RuntimeError{
+				Kind:  object.InternalErrorKind,
+				Error: fmt.Sprintf("Internal error: %v", r),
+			}
+		}
+	}()
+	return withPos(evalExpressions(program.Expressions, env), program)
+}
+
+func eval(node ast.Node, env *object.Environment) object.Object {
 	switch node := node.(type) {
-	case *ast.Program:
-		return evalExpressions(node.Expressions, env)
 	case *ast.AddExpression:
 		return evalExpression(node, node.Exprs, env)
 	case *ast.SubtractExpression:
@@ -48,26 +69,57 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		return evalNegateExpression(Eval(node.Expr, env))
 	case *ast.NotExpression:
 		return evalNotExpression(Eval(node.Expr, env))
+	case *ast.TryExpression:
+		return evalTryExpression(node, env)
 	case *ast.LetExpression:
 		return evalLetExpression(node, env)
 	case *ast.IfExpression:
-		return evalIfExpression(node, env)
+		return evalIfExpression(node, env, Eval)
 	case *ast.Identifier:
 		return evalIdentifier(node, env)
 	case *ast.ListExpression:
 		return evalListExpression(node, env)
+	case *ast.KeywordLiteral:
+		return object.InternKeyword(node.Value)
+	case *ast.MapLiteral:
+		return evalMapLiteral(node, env)
+	case *ast.SetLiteral:
+		return evalSetLiteral(node, env)
 	case *ast.Function:
 		return evalFunctionExpression(node, env)
+	case *ast.FunctionLiteral:
+		return &object.Function{Params: node.Params, Body: node.Body, Env: env}
 	case *ast.CallFunction:
-		return evalCallFunctionExpression(node, env)
+		return evalCallFunctionExpression(node, env, false)
+	case *ast.LoopExpression:
+		return evalLoopExpression(node, env, false)
+	case *ast.RecurExpression:
+		return &object.RuntimeError{
+			Kind:  object.SyntaxErrorKind,
+			Error: "'recur' can only be used in a tail position of 'loop'.",
+		}
+	case *ast.CatchClause:
+		return &object.RuntimeError{
+			Kind:  object.SyntaxErrorKind,
+			Error: "'catch' can only be used as the last expression of 'try'.",
+		}
 	case *ast.OpenExpression:
 		return evalOpenExpression(node, env)
 	case *ast.IntegerLiteral:
+		if node.Big != nil {
+			return &object.BigInteger{Value: node.Big}
+		}
 		return &object.Integer{Value: node.Value}
+	case *ast.FloatLiteral:
+		return &object.Float{Value: node.Value}
+	case *ast.RatioLiteral:
+		return newRatio(node.Value)
 	case *ast.BooleanLiteral:
 		return &object.Boolean{Value: node.Value}
 	case *ast.StringLiteral:
 		return &object.String{Value: node.Value}
+	case *ast.RegexLiteral:
+		return &object.Regex{Value: node.Value}
 	case *ast.NilExpression:
 		return &object.Nil{}
 	default:
@@ -79,15 +131,17 @@ func evalExpression(exprType ast.Node, exprs []ast.Expression, env *object.Envir
 	var accumResult object.Object
 	for _, expr := range exprs {
 		evalExpr := Eval(expr, env)
+		if isError(evalExpr) {
+			return evalExpr
+		}
 		// Declare first value as an accumulator
 		if accumResult == nil {
 			accumResult = evalExpr
 		} else {
 			switch {
-			case evalExpr.Type() == object.IntegerObj && accumResult.Type() == object.IntegerObj:
-				nextValue := evalExpr.(*object.Integer)
+			case isNumber(evalExpr) && isNumber(accumResult):
 				// Evaluate operation by passing current accumulated value and next value
-				accumResult = evalArithmeticOperation(exprType, accumResult.(*object.Integer), nextValue)
+				accumResult = evalArithmeticOperation(exprType, accumResult, evalExpr)
 			case evalExpr.Type() == object.BooleanObj && accumResult.Type() == object.BooleanObj:
 				nextValue := evalExpr.(*object.Boolean)
 				accumResult = evalLogicalOperation(exprType, accumResult.(*object.Boolean), nextValue)
@@ -95,47 +149,21 @@ func evalExpression(exprType ast.Node, exprs []ast.Expression, env *object.Envir
 			// then perform a string concatenation
 			case evalExpr.Type() == object.StringObj || accumResult.Type() == object.StringObj:
 				accumResult = evalStringOperation(exprType, accumResult, evalExpr)
-			case evalExpr.Type() == object.RuntimeErrorObj || accumResult.Type() == object.RuntimeErrorObj:
-				return evalExpr
 			default:
 				return &object.RuntimeError{
+					Kind: object.TypeErrorKind,
 					Error: fmt.Sprintf("Operation %s cannot be performed for types: %s and %s",
 						exprType.String(), accumResult.Type(), evalExpr.Type()),
 				}
 			}
+			if isError(accumResult) {
+				return accumResult
+			}
 		}
 	}
 	return accumResult
 }
 
-func evalArithmeticOperation(exprType ast.Node, left *object.Integer, right *object.Integer) object.Object {
-	switch exprType.(type) {
-	case *ast.AddExpression:
-		return &object.Integer{Value: left.Value + right.Value}
-	case *ast.SubtractExpression:
-		if right.Value < 0 {
-			return &object.Integer{Value: left.Value + right.Value}
-		}
-		return &object.Integer{Value: left.Value - right.Value}
-	case *ast.MultiplyExpression:
-		return &object.Integer{Value: left.Value * right.Value}
-	case *ast.DivideExpression:
-		return &object.Integer{Value: left.Value / right.Value}
-	case *ast.ModuloExpression:
-		return &object.Integer{Value: left.Value % right.Value}
-	case *ast.PowExpression:
-		return &object.Integer{Value: int64(math.Pow(float64(left.Value), float64(right.Value)))}
-	case *ast.EqualExpression:
-		return &object.Boolean{Value: left.Value == right.Value}
-	case *ast.NotEqualExpression:
-		return &object.Boolean{Value: left.Value != right.Value}
-	default:
-		return &object.RuntimeError{
-			Error: fmt.Sprintf("Non-existing operation %s for INTEGER types.", exprType.String()),
-		}
-	}
-}
-
 func evalLogicalOperation(exprType ast.Node, left *object.Boolean, right *object.Boolean) object.Object {
 	switch exprType.(type) {
 	case *ast.AndExpression:
@@ -147,7 +175,10 @@ func evalLogicalOperation(exprType ast.Node, left *object.Boolean, right *object
 	case *ast.NotEqualExpression:
 		return &object.Boolean{Value: left.Value != right.Value}
 	default:
-		return &object.RuntimeError{Error: fmt.Sprintf("Non-existing operation %s for BOOLEAN types.", exprType.String())}
+		return &object.RuntimeError{
+			Kind:  object.TypeErrorKind,
+			Error: fmt.Sprintf("Non-existing operation %s for BOOLEAN types.", exprType.String()),
+		}
 	}
 }
 
@@ -156,7 +187,10 @@ func evalStringOperation(exprType ast.Node, left object.Object, right object.Obj
 	case *ast.AddExpression:
 		return &object.String{Value: left.Inspect() + right.Inspect()}
 	default:
-		return &object.RuntimeError{Error: fmt.Sprintf("Non-existing operation %s for STRING types.", exprType.String())}
+		return &object.RuntimeError{
+			Kind:  object.TypeErrorKind,
+			Error: fmt.Sprintf("Non-existing operation %s for STRING types.", exprType.String()),
+		}
 	}
 }
 
@@ -164,29 +198,13 @@ func evalEqualForAll(exprType ast.Node, exprs []ast.Expression, env *object.Envi
 	var accumResult object.Object
 	for _, expr := range exprs {
 		evalExpr := Eval(expr, env)
+		if isError(evalExpr) {
+			return evalExpr
+		}
 		if accumResult == nil {
 			accumResult = evalExpr
-		} else {
-			switch {
-			case evalExpr.Type() == object.IntegerObj && accumResult.Type() == object.IntegerObj:
-				if accumResult.(*object.Integer).Value != evalExpr.(*object.Integer).Value {
-					return &object.Boolean{Value: false}
-				}
-			case evalExpr.Type() == object.BooleanObj && accumResult.Type() == object.BooleanObj:
-				if accumResult.(*object.Boolean).Value != evalExpr.(*object.Boolean).Value {
-					return &object.Boolean{Value: false}
-				}
-			case evalExpr.Type() == object.NilObj && accumResult.Type() == object.NilObj:
-				continue
-			case evalExpr.Type() == object.NilObj && accumResult.Type() != object.NilObj ||
-				evalExpr.Type() != object.NilObj && accumResult.Type() == object.NilObj:
-				return &object.Boolean{Value: false}
-			default:
-				return &object.RuntimeError{
-					Error: fmt.Sprintf("Operation %s cannot be performed for types: %s and %s",
-						exprType.String(), accumResult.Type(), evalExpr.Type()),
-				}
-			}
+		} else if !objectsEqual(accumResult, evalExpr) {
+			return &object.Boolean{Value: false}
 		}
 	}
 	return &object.Boolean{Value: true}
@@ -202,70 +220,82 @@ func evalNotEqualForAll(exprType ast.Node, exprs []ast.Expression, env *object.E
 	}
 }
 
+// Evaluate a comparison of each two consecutive values. The comparison
+// holds for a result of comparing them, which is negative, zero or positive.
 func evalComparison(exprType ast.Node, exprs []ast.Expression, env *object.Environment,
-	comparator func(left int64, right int64) bool) object.Object {
+	holds func(cmp int) bool) object.Object {
 	var accumResult object.Object
 	for _, expr := range exprs {
 		evalExpr := Eval(expr, env)
+		if isError(evalExpr) {
+			return evalExpr
+		}
 		if accumResult == nil {
 			accumResult = evalExpr
 		} else {
-			switch {
-			case evalExpr.Type() == object.IntegerObj && accumResult.Type() == object.IntegerObj:
-				if comparator(accumResult.(*object.Integer).Value, evalExpr.(*object.Integer).Value) {
-					return &object.Boolean{Value: false}
-				}
-			default:
+			cmp, ok := compareObjects(accumResult, evalExpr)
+			if !ok {
 				return &object.RuntimeError{
+					Kind: object.TypeErrorKind,
 					Error: fmt.Sprintf("Operation %s cannot be performed for types: %s and %s",
 						exprType.String(), accumResult.Type(), evalExpr.Type()),
 				}
 			}
+			if !holds(cmp) {
+				return &object.Boolean{Value: false}
+			}
 		}
 	}
 	return &object.Boolean{Value: true}
 }
 
 func evalGreaterThan(exprType ast.Node, exprs []ast.Expression, env *object.Environment) object.Object {
-	return evalComparison(exprType, exprs, env, func(left int64, right int64) bool {
-		return left <= right
+	return evalComparison(exprType, exprs, env, func(cmp int) bool {
+		return cmp > 0
 	})
 }
 
 func evalGreaterThanEqual(exprType ast.Node, exprs []ast.Expression, env *object.Environment) object.Object {
-	return evalComparison(exprType, exprs, env, func(left int64, right int64) bool {
-		return left < right
+	return evalComparison(exprType, exprs, env, func(cmp int) bool {
+		return cmp >= 0
 	})
 }
 
 func evalLessThan(exprType ast.Node, exprs []ast.Expression, env *object.Environment) object.Object {
-	return evalComparison(exprType, exprs, env, func(left int64, right int64) bool {
-		return left >= right
+	return evalComparison(exprType, exprs, env, func(cmp int) bool {
+		return cmp < 0
 	})
 }
 
 func evalLessThanEqual(exprType ast.Node, exprs []ast.Expression, env *object.Environment) object.Object {
-	return evalComparison(exprType, exprs, env, func(left int64, right int64) bool {
-		return left > right
+	return evalComparison(exprType, exprs, env, func(cmp int) bool {
+		return cmp <= 0
 	})
 }
 
 func evalNegateExpression(value object.Object) object.Object {
-	if value.Type() == object.IntegerObj {
-		v := value.(*object.Integer).Value
-		return &object.Integer{Value: -1 * v}
+	if isError(value) {
+		return value
+	}
+	if isNumber(value) {
+		return negateNumber(value)
 	}
 	return &object.RuntimeError{
+		Kind:  object.TypeErrorKind,
 		Error: fmt.Sprintf("Negation of arithmetic expressions is not applicable for %s type.", value.Type()),
 	}
 }
 
 func evalNotExpression(value object.Object) object.Object {
+	if isError(value) {
+		return value
+	}
 	if value.Type() == object.BooleanObj {
 		v := value.(*object.Boolean).Value
 		return &object.Boolean{Value: !v}
 	}
 	return &object.RuntimeError{
+		Kind:  object.TypeErrorKind,
 		Error: fmt.Sprintf("Negation of logical expressions is not applicable for %s types.", value.Type()),
 	}
 }
@@ -273,84 +303,292 @@ func evalNotExpression(value object.Object) object.Object {
 func evalLetExpression(letExpr *ast.LetExpression, env *object.Environment) object.Object {
 	ident := letExpr.Identifier.String()
 	val := evalExpressions(letExpr.Exprs, env)
+	if isError(val) {
+		return val
+	}
 	env.Set(ident, val)
 	return val
 }
 
 func evalFunctionExpression(f *ast.Function, env *object.Environment) object.Object {
 	ident := f.Identifier.String()
-	fn := &object.Function{Identifier: f.Identifier, Params: f.Params, Body: f.Body}
+	fn := &object.Function{Identifier: f.Identifier, Params: f.Params, Body: f.Body, Env: env}
 	env.Set(ident, fn)
 	return fn
 }
 
-func evalCallFunctionExpression(cf *ast.CallFunction, env *object.Environment) object.Object {
-	fnName := cf.Identifier.String()
-	// First, look for a builtin function
-	builtin, isBuiltin := builtins[fnName]
-	// Then look for a function in the environment
-	// Function from the environment has a priority
-	val, ok := env.Get(fnName)
-	// If the function is not found in the environment, then use builtin
-	if !ok {
-		val = builtin
+// Evaluate a node in a tail position. A call to a user defined function
+// isn't performed here but returned as a TailCall, so that applyFunction
+// can run it in a loop instead of growing the Go stack.
+func evalTail(node ast.Node, env *object.Environment) object.Object {
+	switch node := node.(type) {
+	case *ast.IfExpression:
+		return withPos(evalIfExpression(node, env, evalTail), node)
+	case *ast.CallFunction:
+		return withPos(evalCallFunctionExpression(node, env, true), node)
+	case *ast.LoopExpression:
+		return withPos(evalLoopExpression(node, env, true), node)
+	case *ast.RecurExpression:
+		args, err := evalArgs(node.Exprs, env)
+		if err != nil {
+			return err
+		}
+		return &object.Recur{Args: args}
+	default:
+		return Eval(node, env)
 	}
-	// In a function is not found in both builtins
-	// and in the environment, that function is undefined
-	if !ok && !isBuiltin {
-		return &object.RuntimeError{Error: fmt.Sprintf("Function %s is undefined", fnName)}
+}
+
+func withPos(result object.Object, node ast.Node) object.Object {
+	if runtimeErr, ok := result.(*object.RuntimeError); ok && !runtimeErr.Pos.IsValid() {
+		runtimeErr.Pos = node.Pos()
+	}
+	return result
+}
+
+func evalCallFunctionExpression(cf *ast.CallFunction, env *object.Environment, isTail bool) object.Object {
+	ident, isIdent := cf.Callee.(*ast.Identifier)
+	var val object.Object
+	if isIdent {
+		fn, ok := lookupFunction(ident, env)
+		if !ok {
+			return &object.RuntimeError{
+				Kind:  object.UndefinedErrorKind,
+				Error: fmt.Sprintf("Function %s is undefined", ident.Value),
+			}
+		}
+		val = fn
+	} else {
+		// Any other expression in the head position has to
+		// evaluate to a function in order to be called
+		val = Eval(cf.Callee, env)
+		if isError(val) {
+			return val
+		}
 	}
 	argsCount := len(cf.Args)
 	switch fn := val.(type) {
 	case *object.Function:
-		paramsCount := len(fn.Params)
-		if argsCount > paramsCount {
+		if err := checkArity(fn, argsCount); err != nil {
+			return err
+		}
+		// Arguments are evaluated in the caller's environment while
+		// the body is evaluated within the environment captured
+		// when the function was defined.
+		args, err := evalArgs(cf.Args, env)
+		if err != nil {
+			return err
+		}
+		if isTail {
+			return &object.TailCall{Fn: fn, Args: args, CallPos: cf.Pos()}
+		}
+		return applyFunction(fn, args, cf.Pos())
+	case *object.Builtin:
+		args, err := evalArgs(cf.Args, env)
+		if err != nil {
+			return err
+		}
+		result := fn.Fn(args...)
+		// A function applied by the builtin doesn't know where it
+		// was called from, so the call of the builtin is recorded
+		if runtimeErr, ok := result.(*object.RuntimeError); ok && len(runtimeErr.Frames) > 0 {
+			if outermost := &runtimeErr.Frames[len(runtimeErr.Frames)-1]; !outermost.CallPos.IsValid() {
+				outermost.CallPos = cf.Pos()
+			}
+		}
+		return result
+	case *object.Keyword:
+		if argsCount == 0 {
+			return fn
+		}
+		args, err := evalArgs(cf.Args, env)
+		if err != nil {
+			return err
+		}
+		return lookupKeyword(fn, args)
+	default:
+		if argsCount > 0 && isIdent {
 			return &object.RuntimeError{
-				Error: fmt.Sprintf("Too many arguments. Expected %d, got %d.", paramsCount, argsCount),
+				Kind:  object.ArityErrorKind,
+				Error: fmt.Sprintf("Identifiers do not take any arguments. Found %d.", argsCount),
 			}
 		}
-		if argsCount < paramsCount {
+		if argsCount > 0 {
+			callee := cf.Callee.String()
+			// A string literal is shown in quotes, so that it doesn't look like an identifier
+			if _, ok := cf.Callee.(*ast.StringLiteral); ok {
+				callee = val.Repr()
+			}
 			return &object.RuntimeError{
-				Error: fmt.Sprintf("Insufficient number of arguments. Expected %d, got %d.", paramsCount, argsCount),
+				Kind:  object.TypeErrorKind,
+				Error: fmt.Sprintf("Expression %s is not callable. It evaluates to %s type.", callee, val.Type()),
 			}
 		}
-		innerEnv := object.NewInnerEnvironment(env)
-		for idx, param := range fn.Params {
-			innerEnv.Set(param.Value, Eval(cf.Args[idx], innerEnv))
+		return val
+	}
+}
+
+// Apply calls a function, a builtin or a keyword with already evaluated arguments.
+func Apply(fn object.Object, args ...object.Object) object.Object {
+	switch fn := fn.(type) {
+	case *object.Function:
+		if err := checkArity(fn, len(args)); err != nil {
+			return err
 		}
-		return evalExpressions(fn.Body, innerEnv)
+		return applyFunction(fn, args, token.Position{})
 	case *object.Builtin:
-		var args []object.Object
-		for _, a := range cf.Args {
-			args = append(args, Eval(a, env))
-		}
 		return fn.Fn(args...)
+	case *object.Keyword:
+		if len(args) == 0 {
+			return checkArgsCount(args, 1)
+		}
+		return lookupKeyword(fn, args)
 	default:
-		if argsCount > 0 {
-			return &object.RuntimeError{
-				Error: fmt.Sprintf("Identifiers do not take any arguments. Found %d.", argsCount),
+		return &object.RuntimeError{
+			Kind:  object.TypeErrorKind,
+			Error: fmt.Sprintf("Value of %s type is not callable.", fn.Type()),
+		}
+	}
+}
+
+// Check whether the function can be called with the number of arguments.
+func checkArity(fn *object.Function, argsCount int) *object.RuntimeError {
+	paramsCount := len(fn.Params)
+	if argsCount > paramsCount {
+		return &object.RuntimeError{
+			Kind:  object.ArityErrorKind,
+			Error: fmt.Sprintf("Too many arguments. Expected %d, got %d.", paramsCount, argsCount),
+		}
+	}
+	if argsCount < paramsCount {
+		return &object.RuntimeError{
+			Kind:  object.ArityErrorKind,
+			Error: fmt.Sprintf("Insufficient number of arguments. Expected %d, got %d.", paramsCount, argsCount),
+		}
+	}
+	return nil
+}
+
+// Apply a user defined function to already evaluated arguments.
+// Tail calls returned from the body are run in the same loop,
+// so tail recursion takes a constant amount of the Go stack.
+// Their frames are reported at the position of the original call.
+func applyFunction(fn *object.Function, args []object.Object, callPos token.Position) object.Object {
+	for {
+		innerEnv := object.NewInnerEnvironment(fn.Env)
+		for idx, param := range fn.Params {
+			innerEnv.Set(param.Value, args[idx])
+		}
+		result := evalTailExpressions(fn.Body, innerEnv)
+		if runtimeErr, ok := result.(*object.RuntimeError); ok {
+			// Record the function which the error passed through
+			runtimeErr.Frames = append(runtimeErr.Frames, object.Frame{Function: fn.Name(), CallPos: callPos})
+			return runtimeErr
+		}
+		tailCall, ok := result.(*object.TailCall)
+		if !ok {
+			return result
+		}
+		fn, args = tailCall.Fn, tailCall.Args
+	}
+}
+
+// Evaluate the loop body until it evaluates to something else than 'recur'.
+// If the loop itself is in a tail position, a tail call from its body
+// is left to the enclosing function to perform.
+func evalLoopExpression(loop *ast.LoopExpression, env *object.Environment, isTail bool) object.Object {
+	loopEnv := object.NewInnerEnvironment(env)
+	for idx, binding := range loop.Bindings {
+		init := Eval(loop.Inits[idx], loopEnv)
+		if isError(init) {
+			return init
+		}
+		loopEnv.Set(binding.Value, init)
+	}
+	for {
+		result := evalTailExpressions(loop.Body, loopEnv)
+		switch res := result.(type) {
+		case *object.Recur:
+			argsCount := len(res.Args)
+			bindingsCount := len(loop.Bindings)
+			if argsCount > bindingsCount {
+				return &object.RuntimeError{
+					Kind:  object.ArityErrorKind,
+					Error: fmt.Sprintf("Too many arguments. Expected %d, got %d.", bindingsCount, argsCount),
+				}
+			}
+			if argsCount < bindingsCount {
+				return &object.RuntimeError{
+					Kind:  object.ArityErrorKind,
+					Error: fmt.Sprintf("Insufficient number of arguments. Expected %d, got %d.", bindingsCount, argsCount),
+				}
 			}
+			loopEnv = object.NewInnerEnvironment(env)
+			for idx, binding := range loop.Bindings {
+				loopEnv.Set(binding.Value, res.Args[idx])
+			}
+		case *object.TailCall:
+			if isTail {
+				return res
+			}
+			return applyFunction(res.Fn, res.Args, res.CallPos)
+		default:
+			return result
 		}
-		return val
 	}
 }
 
-func evalIfExpression(ifExpr *ast.IfExpression, env *object.Environment) object.Object {
+// Evaluate the body of 'try'. If it results in an error,
+// the error is bound to the identifier of 'catch' and
+// the body of 'catch' is evaluated instead.
+func evalTryExpression(tryExpr *ast.TryExpression, env *object.Environment) object.Object {
+	result := evalExpressions(tryExpr.Body, env)
+	runtimeErr, ok := result.(*object.RuntimeError)
+	if !ok {
+		return result
+	}
+	catchEnv := object.NewInnerEnvironment(env)
+	catchEnv.Set(tryExpr.Catch.Identifier.Value, &object.Error{
+		Message: runtimeErr.Error,
+		Kind:    runtimeErr.Kind,
+		Value:   runtimeErr.Value,
+	})
+	return evalExpressions(tryExpr.Catch.Body, catchEnv)
+}
+
+func lookupFunction(ident *ast.Identifier, env *object.Environment) (object.Object, bool) {
+	// Function from the environment has a priority over a builtin
+	if val, ok := env.Get(ident.Value); ok {
+		return val, true
+	}
+	if builtin, ok := builtins[ident.Value]; ok {
+		return builtin, true
+	}
+	return nil, false
+}
+
+// Branches are evaluated with the given eval function, so they
+// can be evaluated in a tail position as well.
+func evalIfExpression(ifExpr *ast.IfExpression, env *object.Environment,
+	eval func(ast.Node, *object.Environment) object.Object) object.Object {
 	cond := Eval(ifExpr.Condition, env)
+	if isError(cond) {
+		return cond
+	}
 	switch cnd := cond.(type) {
 	case *object.Boolean:
 		if cnd.Value {
-			eval := Eval(ifExpr.ThenExpr, env)
-			return eval
+			return eval(ifExpr.ThenExpr, env)
 		}
 		if ifExpr.ElseExpr != nil && !cnd.Value {
-			return Eval(ifExpr.ElseExpr, env)
+			return eval(ifExpr.ElseExpr, env)
 		}
 		// If a condition is not satisfied and an else expression is missing,
 		// return a Noop object which doesn't contain anything.
 		return &object.Noop{}
 	}
 	return &object.RuntimeError{
+		Kind:  object.TypeErrorKind,
 		Error: fmt.Sprintf("Condition for if expression should evaluate to BOOLEAN type. Found %s type.", cond.Type()),
 	}
 }
@@ -359,14 +597,57 @@ func evalListExpression(listExpression *ast.ListExpression, env *object.Environm
 	list := &object.List{Objects: []object.Object{}}
 	for _, expr := range listExpression.Exprs {
 		res := Eval(expr, env)
+		if isError(res) {
+			return res
+		}
 		list.Objects = append(list.Objects, res)
 	}
 	return list
 }
 
+func evalMapLiteral(mapLiteral *ast.MapLiteral, env *object.Environment) object.Object {
+	m := object.NewMap()
+	for i, keyExpr := range mapLiteral.Keys {
+		key := Eval(keyExpr, env)
+		if isError(key) {
+			return key
+		}
+		hashable, err := toHashable(key)
+		if err != nil {
+			return withPos(err, keyExpr)
+		}
+		value := Eval(mapLiteral.Values[i], env)
+		if isError(value) {
+			return value
+		}
+		m.Set(hashable, value)
+	}
+	return m
+}
+
+func evalSetLiteral(setLiteral *ast.SetLiteral, env *object.Environment) object.Object {
+	set := object.NewSet()
+	for _, expr := range setLiteral.Elements {
+		element := Eval(expr, env)
+		if isError(element) {
+			return element
+		}
+		hashable, err := toHashable(element)
+		if err != nil {
+			return withPos(err, expr)
+		}
+		set.Add(hashable)
+	}
+	return set
+}
+
 func evalIdentifier(ident *ast.Identifier, env *object.Environment) object.Object {
 	val, ok := env.Get(ident.Value)
 	if !ok {
+		// Builtins can be passed around as values
+		if builtin, isBuiltin := builtins[ident.Value]; isBuiltin {
+			return builtin
+		}
 		return &object.Nil{}
 	}
 	return val
@@ -377,20 +658,22 @@ func evalOpenExpression(openExpr *ast.OpenExpression, env *object.Environment) o
 	arr, err := ioutil.ReadFile(file + ".bell")
 	if err != nil {
 		return &object.RuntimeError{
+			Kind:  object.FileErrorKind,
 			Error: fmt.Sprintf("Cannot open '%s'. File not found.", file),
 		}
 	}
-	lxr := lexer.New(string(arr))
+	lxr := lexer.NewWithFileName(file+".bell", string(arr))
 	p := parser.New(lxr)
 	program := p.ParseProgram()
 	if len(p.Errors) > 0 {
-		for _, err := range p.Errors {
-			return &object.RuntimeError{
-				Error: err,
-			}
+		return &object.RuntimeError{
+			Kind:  object.SyntaxErrorKind,
+			Error: p.Errors[0].Message,
+			Pos:   p.Errors[0].Pos,
 		}
-	} else {
-		Eval(program, env)
+	}
+	if result := Eval(program, env); isError(result) {
+		return result
 	}
 	return &object.Noop{}
 }
@@ -399,11 +682,39 @@ func evalExpressions(exprs []ast.Expression, env *object.Environment) object.Obj
 	var result object.Object
 	for _, expr := range exprs {
 		result = Eval(expr, env)
-		switch result.(type) {
-		case *object.RuntimeError:
-			fmt.Println(result.Inspect())
+		// Errors aren't reported here. They are returned
+		// to the caller of Eval which decides how to report them.
+		if isError(result) {
 			return result
 		}
 	}
 	return result
 }
+
+// Evaluate a function body where the last expression is in a tail position
+func evalTailExpressions(exprs []ast.Expression, env *object.Environment) object.Object {
+	last := len(exprs) - 1
+	result := evalExpressions(exprs[:last], env)
+	if isError(result) {
+		return result
+	}
+	return evalTail(exprs[last], env)
+}
+
+// Evaluate arguments from left to right. The first error
+// stops the evaluation and is returned instead of arguments.
+func evalArgs(exprs []ast.Expression, env *object.Environment) ([]object.Object, object.Object) {
+	var args []object.Object
+	for _, expr := range exprs {
+		arg := Eval(expr, env)
+		if isError(arg) {
+			return nil, arg
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+func isError(obj object.Object) bool {
+	return obj != nil && obj.Type() == object.RuntimeErrorObj
+}
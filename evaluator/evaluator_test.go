@@ -0,0 +1,79 @@
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/branislavlazic/bell/lexer"
+	"github.com/branislavlazic/bell/object"
+	"github.com/branislavlazic/bell/parser"
+)
+
+func TestEval_RecoverFromPanic(t *testing.T) {
+	builtins["explode"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			panic("unexpected state")
+		},
+	}
+	defer delete(builtins, "explode")
+
+	l := lexer.New(`(+ 1 2) (explode)`)
+	p := parser.New(l)
+	program := p.ParseProgram()
+	result := Eval(program, object.NewEnvironment())
+
+	runtimeErr, ok := result.(*object.RuntimeError)
+	if !ok {
+		t.Fatalf("test - wrong result type. expected=%s, got=%s", object.RuntimeErrorObj, result.Type())
+	}
+	if runtimeErr.Kind != object.InternalErrorKind {
+		t.Fatalf("test - wrong error kind. expected=%s, got=%s", object.InternalErrorKind, runtimeErr.Kind)
+	}
+	if runtimeErr.Error != "Internal error: unexpected state" {
+		t.Fatalf("test - wrong error. expected=%s, got=%s", "Internal error: unexpected state", runtimeErr.Error)
+	}
+}
+
+func TestRepr_RoundTrip(t *testing.T) {
+	inputs := []string{
+		`(list "a b" "c" (list 1 (list 2)))`,
+		`("quote \" backslash \\ tab \t line \n")`,
+		`(list -7 9223372036854775808 3/4 -1/2 2.5 1e-9 true nil :name)`,
+		`({"a" (list 1 2) :b {1 #{"x" :y}} nil false})`,
+		`(#{1 "1" :one})`,
+		`(list "\${x}" "$ {" "$")`,
+		`(list #"\d+\"" (re "say \"hi\"") #"a\\")`,
+	}
+	for i, input := range inputs {
+		first := evalInput(t, input)
+		second := evalInput(t, "("+first.Repr()+")")
+		if !objectsEqual(first, second) {
+			t.Fatalf("tests[%d] - value changed. expected=%s, got=%s", i, first.Repr(), second.Repr())
+		}
+		if first.Repr() != second.Repr() {
+			t.Fatalf("tests[%d] - wrong repr. expected=%s, got=%s", i, first.Repr(), second.Repr())
+		}
+	}
+}
+
+func TestRepr_NonFiniteFloats(t *testing.T) {
+	input := `(list (float "NaN") (^ 10.0 400) (- (^ 10.0 400)))`
+	expected := `(list (float "NaN") (float "+Inf") (float "-Inf"))`
+	first := evalInput(t, input)
+	if first.Repr() != expected {
+		t.Fatalf("test - wrong repr. expected=%s, got=%s", expected, first.Repr())
+	}
+	second := evalInput(t, "("+first.Repr()+")")
+	if second.Repr() != expected {
+		t.Fatalf("test - wrong repr after reading it back. expected=%s, got=%s", expected, second.Repr())
+	}
+}
+
+func evalInput(t *testing.T, input string) object.Object {
+	l := lexer.New(input)
+	p := parser.New(l)
+	program := p.ParseProgram()
+	if len(p.Errors) != 0 {
+		t.Fatalf("test - unexpected error for %s: %s", input, p.Errors[0])
+	}
+	return Eval(program, object.NewEnvironment())
+}
@@ -0,0 +1,281 @@
+package evaluator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/branislavlazic/bell/object"
+)
+
+// Builtins which apply functions passed to them. They're registered
+// in init, since applying a function refers back to the builtins.
+func init() {
+	builtins["map"] = &object.Builtin{Fn: mapBuiltin}
+	builtins["filter"] = &object.Builtin{Fn: filterBuiltin}
+	builtins["reduce"] = &object.Builtin{Fn: reduceBuiltin}
+	builtins["some"] = &object.Builtin{Fn: someBuiltin}
+	builtins["every?"] = &object.Builtin{Fn: everyBuiltin}
+	builtins["sort"] = &object.Builtin{Fn: sortBuiltin}
+	builtins["sort-by"] = &object.Builtin{Fn: sortByBuiltin}
+	builtins["group-by"] = &object.Builtin{Fn: groupByBuiltin}
+	builtins["apply"] = &object.Builtin{Fn: applyBuiltin}
+}
+
+// Apply the function to elements of the collections at the same
+// positions. It stops at the end of the shortest collection.
+func mapBuiltin(args ...object.Object) object.Object {
+	if len(args) < 2 {
+		return &object.RuntimeError{
+			Kind:  object.ArityErrorKind,
+			Error: fmt.Sprintf("Insufficient number of arguments. Expected at least %d, got %d.", 2, len(args)),
+		}
+	}
+	// The deprecated order of the former stdlib map, (map lst func)
+	if len(args) == 2 && !isCallable(args[0]) && isCallable(args[1]) {
+		args = []object.Object{args[1], args[0]}
+	}
+	var colls [][]object.Object
+	for _, arg := range args[1:] {
+		elements, err := toSeq(arg)
+		if err != nil {
+			return err
+		}
+		colls = append(colls, elements)
+	}
+	var results []object.Object
+	for i := 0; ; i++ {
+		var fnArgs []object.Object
+		for _, elements := range colls {
+			if i >= len(elements) {
+				return newList(results)
+			}
+			fnArgs = append(fnArgs, elements[i])
+		}
+		result := Apply(args[0], fnArgs...)
+		if isError(result) {
+			return result
+		}
+		results = append(results, result)
+	}
+}
+
+func filterBuiltin(args ...object.Object) object.Object {
+	if err := checkArgsCount(args, 2); err != nil {
+		return err
+	}
+	elements, err := toSeq(args[1])
+	if err != nil {
+		return err
+	}
+	var results []object.Object
+	for _, element := range elements {
+		ok, err := applyPredicate(args[0], element)
+		if err != nil {
+			return err
+		}
+		if ok {
+			results = append(results, element)
+		}
+	}
+	return newList(results)
+}
+
+// Combine elements from the left with the function, starting either with the
+// initial value, or with the first element when it isn't given.
+func reduceBuiltin(args ...object.Object) object.Object {
+	if len(args) < 2 {
+		return checkArgsCount(args, 2)
+	} else if len(args) > 3 {
+		return checkArgsCount(args, 3)
+	}
+	elements, err := toSeq(args[len(args)-1])
+	if err != nil {
+		return err
+	}
+	var accumulator object.Object
+	if len(args) == 3 {
+		accumulator = args[1]
+	} else if len(elements) > 0 {
+		accumulator, elements = elements[0], elements[1:]
+	} else {
+		return &object.Nil{}
+	}
+	for _, element := range elements {
+		accumulator = Apply(args[0], accumulator, element)
+		if isError(accumulator) {
+			return accumulator
+		}
+	}
+	return accumulator
+}
+
+func someBuiltin(args ...object.Object) object.Object {
+	if err := checkArgsCount(args, 2); err != nil {
+		return err
+	}
+	elements, err := toSeq(args[1])
+	if err != nil {
+		return err
+	}
+	for _, element := range elements {
+		ok, err := applyPredicate(args[0], element)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return &object.Boolean{Value: true}
+		}
+	}
+	return &object.Boolean{Value: false}
+}
+
+func everyBuiltin(args ...object.Object) object.Object {
+	if err := checkArgsCount(args, 2); err != nil {
+		return err
+	}
+	elements, err := toSeq(args[1])
+	if err != nil {
+		return err
+	}
+	for _, element := range elements {
+		ok, err := applyPredicate(args[0], element)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return &object.Boolean{Value: false}
+		}
+	}
+	return &object.Boolean{Value: true}
+}
+
+func sortBuiltin(args ...object.Object) object.Object {
+	if err := checkArgsCount(args, 1); err != nil {
+		return err
+	}
+	elements, err := toSeq(args[0])
+	if err != nil {
+		return err
+	}
+	return sortByKeys(elements, elements)
+}
+
+func sortByBuiltin(args ...object.Object) object.Object {
+	if err := checkArgsCount(args, 2); err != nil {
+		return err
+	}
+	elements, err := toSeq(args[1])
+	if err != nil {
+		return err
+	}
+	keys := make([]object.Object, len(elements))
+	for i, element := range elements {
+		keys[i] = Apply(args[0], element)
+		if isError(keys[i]) {
+			return keys[i]
+		}
+	}
+	return sortByKeys(elements, keys)
+}
+
+// Group elements into a map by results of the function.
+func groupByBuiltin(args ...object.Object) object.Object {
+	if err := checkArgsCount(args, 2); err != nil {
+		return err
+	}
+	elements, err := toSeq(args[1])
+	if err != nil {
+		return err
+	}
+	groups := object.NewMap()
+	for _, element := range elements {
+		result := Apply(args[0], element)
+		if isError(result) {
+			return result
+		}
+		key, err := toHashable(result)
+		if err != nil {
+			return err
+		}
+		// Lists of groups aren't shared yet, so they can be extended
+		if group, ok := groups.Get(key); ok {
+			group := group.(*object.List)
+			group.Objects = append(group.Objects, element)
+		} else {
+			groups.Set(key, &object.List{Objects: []object.Object{element}})
+		}
+	}
+	return groups
+}
+
+// Apply the function to the arguments, where the last one is a list
+// of further arguments, e.g. (apply f 1 (list 2 3)) is (f 1 2 3).
+func applyBuiltin(args ...object.Object) object.Object {
+	if len(args) < 2 {
+		return &object.RuntimeError{
+			Kind:  object.ArityErrorKind,
+			Error: fmt.Sprintf("Insufficient number of arguments. Expected at least %d, got %d.", 2, len(args)),
+		}
+	}
+	rest, err := toObjects(args[len(args)-1])
+	if err != nil {
+		return err
+	}
+	fnArgs := append(append([]object.Object{}, args[1:len(args)-1]...), rest...)
+	return Apply(args[0], fnArgs...)
+}
+
+func isCallable(obj object.Object) bool {
+	switch obj.(type) {
+	case *object.Function, *object.Builtin, *object.Keyword:
+		return true
+	default:
+		return false
+	}
+}
+
+// Apply the predicate to the element. Like a condition
+// of 'if', it has to evaluate to a boolean.
+func applyPredicate(predicate object.Object, element object.Object) (bool, *object.RuntimeError) {
+	result := Apply(predicate, element)
+	if runtimeErr, ok := result.(*object.RuntimeError); ok {
+		return false, runtimeErr
+	}
+	boolean, ok := result.(*object.Boolean)
+	if !ok {
+		return false, &object.RuntimeError{
+			Kind:  object.TypeErrorKind,
+			Error: fmt.Sprintf("Predicate should evaluate to BOOLEAN type. Found %s type.", result.Type()),
+		}
+	}
+	return boolean.Value, nil
+}
+
+// Sort the elements in the ascending order of their keys.
+// Elements with equal keys keep their order.
+func sortByKeys(elements []object.Object, keys []object.Object) object.Object {
+	indexes := make([]int, len(elements))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	var err *object.RuntimeError
+	sort.SliceStable(indexes, func(i, j int) bool {
+		left, right := keys[indexes[i]], keys[indexes[j]]
+		cmp, ok := compareObjects(left, right)
+		if !ok && err == nil {
+			err = &object.RuntimeError{
+				Kind:  object.TypeErrorKind,
+				Error: fmt.Sprintf("Values of types %s and %s cannot be compared.", left.Type(), right.Type()),
+			}
+		}
+		return cmp < 0
+	})
+	if err != nil {
+		return err
+	}
+	sorted := make([]object.Object, len(elements))
+	for i, index := range indexes {
+		sorted[i] = elements[index]
+	}
+	return newList(sorted)
+}
@@ -0,0 +1,400 @@
+package evaluator
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/branislavlazic/bell/ast"
+	"github.com/branislavlazic/bell/object"
+)
+
+// Numbers of different types are promoted to the wider type
+// before an operation is performed: INTEGER -> RATIO -> FLOAT.
+// Integers are kept as int64 values, until an operation
+// overflows. Then they're promoted to arbitrary-precision
+// integers, and demoted back when the result fits into int64.
+// Similarly, a ratio with the denominator 1 becomes an integer.
+
+func isNumber(obj object.Object) bool {
+	switch obj.(type) {
+	case *object.Integer, *object.BigInteger, *object.Ratio, *object.Float:
+		return true
+	default:
+		return false
+	}
+}
+
+func isInteger(obj object.Object) bool {
+	switch obj.(type) {
+	case *object.Integer, *object.BigInteger:
+		return true
+	default:
+		return false
+	}
+}
+
+func toFloat(obj object.Object) float64 {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return float64(obj.Value)
+	case *object.BigInteger:
+		value, _ := new(big.Float).SetInt(obj.Value).Float64()
+		return value
+	case *object.Ratio:
+		value, _ := obj.Value.Float64()
+		return value
+	case *object.Float:
+		return obj.Value
+	default:
+		return math.NaN()
+	}
+}
+
+func toBigInt(obj object.Object) *big.Int {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return big.NewInt(obj.Value)
+	case *object.BigInteger:
+		return obj.Value
+	default:
+		return nil
+	}
+}
+
+func toRat(obj object.Object) *big.Rat {
+	switch obj := obj.(type) {
+	case *object.Ratio:
+		return obj.Value
+	default:
+		return new(big.Rat).SetInt(toBigInt(obj))
+	}
+}
+
+// Give a ratio, or an integer if the denominator is 1.
+func newRatio(value *big.Rat) object.Object {
+	if value.IsInt() {
+		return newInteger(new(big.Int).Set(value.Num()))
+	}
+	return &object.Ratio{Value: value}
+}
+
+// Give the smallest integer object which can hold the value.
+func newInteger(value *big.Int) object.Object {
+	if value.IsInt64() {
+		return &object.Integer{Value: value.Int64()}
+	}
+	return &object.BigInteger{Value: value}
+}
+
+func evalArithmeticOperation(exprType ast.Node, left object.Object, right object.Object) object.Object {
+	if left.Type() == object.FloatObj || right.Type() == object.FloatObj {
+		return evalFloatOperation(exprType, toFloat(left), toFloat(right))
+	}
+	if left.Type() == object.RatioObj || right.Type() == object.RatioObj {
+		return evalRatioOperation(exprType, toRat(left), toRat(right))
+	}
+	l, lok := left.(*object.Integer)
+	r, rok := right.(*object.Integer)
+	if lok && rok {
+		if result, ok := evalIntegerOperation(exprType, l.Value, r.Value); ok {
+			return result
+		}
+	}
+	return evalBigIntegerOperation(exprType, toBigInt(left), toBigInt(right))
+}
+
+// Evaluate an operation on int64 values. If the result
+// overflows, it isn't ok and big integers have to be used.
+func evalIntegerOperation(exprType ast.Node, left int64, right int64) (object.Object, bool) {
+	switch exprType.(type) {
+	case *ast.AddExpression:
+		result := left + right
+		if (left > 0 && right > 0 && result < 0) || (left < 0 && right < 0 && result >= 0) {
+			return nil, false
+		}
+		return &object.Integer{Value: result}, true
+	case *ast.SubtractExpression:
+		result := left - right
+		if (left >= 0 && right < 0 && result < 0) || (left < 0 && right > 0 && result >= 0) {
+			return nil, false
+		}
+		return &object.Integer{Value: result}, true
+	case *ast.MultiplyExpression:
+		if left == 0 || right == 0 {
+			return &object.Integer{Value: 0}, true
+		}
+		result := left * right
+		if result/right != left || (left == -1 && right == math.MinInt64) || (right == -1 && left == math.MinInt64) {
+			return nil, false
+		}
+		return &object.Integer{Value: result}, true
+	case *ast.DivideExpression:
+		if right == 0 {
+			return divisionByZeroError(), true
+		}
+		if left == math.MinInt64 && right == -1 {
+			return nil, false
+		}
+		return &object.Integer{Value: left / right}, true
+	case *ast.ModuloExpression:
+		if right == 0 {
+			return moduloByZeroError(), true
+		}
+		return &object.Integer{Value: left % right}, true
+	case *ast.PowExpression:
+		if right < 0 {
+			return negativeExponentError(right), true
+		}
+		return nil, false
+	default:
+		return &object.RuntimeError{
+			Kind:  object.TypeErrorKind,
+			Error: fmt.Sprintf("Non-existing operation %s for INTEGER types.", exprType.String()),
+		}, true
+	}
+}
+
+func evalBigIntegerOperation(exprType ast.Node, left *big.Int, right *big.Int) object.Object {
+	result := new(big.Int)
+	switch exprType.(type) {
+	case *ast.AddExpression:
+		result.Add(left, right)
+	case *ast.SubtractExpression:
+		result.Sub(left, right)
+	case *ast.MultiplyExpression:
+		result.Mul(left, right)
+	case *ast.DivideExpression:
+		if right.Sign() == 0 {
+			return divisionByZeroError()
+		}
+		// Truncated division, same as for int64 values
+		result.Quo(left, right)
+	case *ast.ModuloExpression:
+		if right.Sign() == 0 {
+			return moduloByZeroError()
+		}
+		result.Rem(left, right)
+	case *ast.PowExpression:
+		if right.Sign() < 0 {
+			return negativeExponentError(right)
+		}
+		if err := checkPowerSize(left.BitLen(), right); err != nil {
+			return err
+		}
+		result.Exp(left, right, nil)
+	default:
+		return &object.RuntimeError{
+			Kind:  object.TypeErrorKind,
+			Error: fmt.Sprintf("Non-existing operation %s for INTEGER types.", exprType.String()),
+		}
+	}
+	return newInteger(result)
+}
+
+func evalRatioOperation(exprType ast.Node, left *big.Rat, right *big.Rat) object.Object {
+	result := new(big.Rat)
+	switch exprType.(type) {
+	case *ast.AddExpression:
+		result.Add(left, right)
+	case *ast.SubtractExpression:
+		result.Sub(left, right)
+	case *ast.MultiplyExpression:
+		result.Mul(left, right)
+	case *ast.DivideExpression:
+		if right.Sign() == 0 {
+			return divisionByZeroError()
+		}
+		result.Quo(left, right)
+	case *ast.ModuloExpression:
+		if right.Sign() == 0 {
+			return moduloByZeroError()
+		}
+		// Remainder of the truncated division, so it has the sign of the dividend
+		quotient := new(big.Rat).SetInt(truncRat(new(big.Rat).Quo(left, right)))
+		result.Sub(left, quotient.Mul(quotient, right))
+	case *ast.PowExpression:
+		if !right.IsInt() {
+			return &object.Float{Value: math.Pow(toFloat(newRatio(left)), toFloat(newRatio(right)))}
+		}
+		if left.Sign() == 0 && right.Sign() < 0 {
+			return divisionByZeroError()
+		}
+		exponent := new(big.Int).Abs(right.Num())
+		bitLen := left.Num().BitLen()
+		if left.Denom().BitLen() > bitLen {
+			bitLen = left.Denom().BitLen()
+		}
+		if err := checkPowerSize(bitLen, exponent); err != nil {
+			return err
+		}
+		result.SetFrac(
+			new(big.Int).Exp(left.Num(), exponent, nil),
+			new(big.Int).Exp(left.Denom(), exponent, nil),
+		)
+		if right.Sign() < 0 {
+			result.Inv(result)
+		}
+	default:
+		return &object.RuntimeError{
+			Kind:  object.TypeErrorKind,
+			Error: fmt.Sprintf("Non-existing operation %s for RATIO types.", exprType.String()),
+		}
+	}
+	return newRatio(result)
+}
+
+func evalFloatOperation(exprType ast.Node, left float64, right float64) object.Object {
+	switch exprType.(type) {
+	case *ast.AddExpression:
+		return &object.Float{Value: left + right}
+	case *ast.SubtractExpression:
+		return &object.Float{Value: left - right}
+	case *ast.MultiplyExpression:
+		return &object.Float{Value: left * right}
+	case *ast.DivideExpression:
+		if right == 0 {
+			return divisionByZeroError()
+		}
+		return &object.Float{Value: left / right}
+	case *ast.ModuloExpression:
+		if right == 0 {
+			return moduloByZeroError()
+		}
+		return &object.Float{Value: math.Mod(left, right)}
+	case *ast.PowExpression:
+		return &object.Float{Value: math.Pow(left, right)}
+	default:
+		return &object.RuntimeError{
+			Kind:  object.TypeErrorKind,
+			Error: fmt.Sprintf("Non-existing operation %s for FLOAT types.", exprType.String()),
+		}
+	}
+}
+
+func divisionByZeroError() *object.RuntimeError {
+	return &object.RuntimeError{Kind: object.ArithmeticErrorKind, Error: "Division by zero."}
+}
+
+func moduloByZeroError() *object.RuntimeError {
+	return &object.RuntimeError{Kind: object.ArithmeticErrorKind, Error: "Modulo by zero."}
+}
+
+func negativeExponentError(exponent interface{}) *object.RuntimeError {
+	return &object.RuntimeError{
+		Kind:  object.ArithmeticErrorKind,
+		Error: fmt.Sprintf("Integers cannot be raised to a negative power %v. Use a ratio or a float instead.", exponent),
+	}
+}
+
+// Maximum number of bits of a power, so that
+// a huge power can't exhaust the memory.
+const maxPowerBits = 1 << 24
+
+// Check whether a power of a base with the number of bits
+// fits into the limit. Bases 0, 1 and -1 fit for any exponent.
+func checkPowerSize(baseBitLen int, exponent *big.Int) *object.RuntimeError {
+	if baseBitLen <= 1 {
+		return nil
+	}
+	if !exponent.IsInt64() || exponent.Int64() > maxPowerBits/int64(baseBitLen) {
+		return &object.RuntimeError{
+			Kind:  object.ArithmeticErrorKind,
+			Error: "Result of the power is too large.",
+		}
+	}
+	return nil
+}
+
+func negateNumber(value object.Object) object.Object {
+	switch value := value.(type) {
+	case *object.Integer:
+		if value.Value == math.MinInt64 {
+			return newInteger(new(big.Int).Neg(big.NewInt(value.Value)))
+		}
+		return &object.Integer{Value: -1 * value.Value}
+	case *object.BigInteger:
+		return newInteger(new(big.Int).Neg(value.Value))
+	case *object.Ratio:
+		return newRatio(new(big.Rat).Neg(value.Value))
+	default:
+		return &object.Float{Value: -1 * toFloat(value)}
+	}
+}
+
+// Compare two numbers. The result is negative when the left number
+// is less than the right one, zero when they're equal and positive
+// when the left number is greater.
+func compareNumbers(left object.Object, right object.Object) int {
+	if isInteger(left) && isInteger(right) {
+		l, lok := left.(*object.Integer)
+		r, rok := right.(*object.Integer)
+		if lok && rok {
+			return compareInts(l.Value, r.Value)
+		}
+		return toBigInt(left).Cmp(toBigInt(right))
+	}
+	if left.Type() != object.FloatObj && right.Type() != object.FloatObj {
+		return toRat(left).Cmp(toRat(right))
+	}
+	l, r := toFloat(left), toFloat(right)
+	switch {
+	case l < r:
+		return -1
+	case l > r:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareInts(left int64, right int64) int {
+	switch {
+	case left < right:
+		return -1
+	case left > right:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Convert a float to an integer. The float must not have a fraction.
+func floatToInteger(value float64) object.Object {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return &object.RuntimeError{
+			Kind:  object.TypeErrorKind,
+			Error: fmt.Sprintf("Cannot convert %s to INTEGER type.", (&object.Float{Value: value}).Inspect()),
+		}
+	}
+	if value >= math.MinInt64 && value < math.MaxInt64 {
+		return &object.Integer{Value: int64(value)}
+	}
+	result, _ := big.NewFloat(value).Int(nil)
+	return newInteger(result)
+}
+
+// Functions rounding a ratio to an integer
+
+func truncRat(value *big.Rat) *big.Int {
+	return new(big.Int).Quo(value.Num(), value.Denom())
+}
+
+func floorRat(value *big.Rat) *big.Int {
+	// The denominator is always positive, so
+	// the Euclidean division rounds down
+	return new(big.Int).Div(value.Num(), value.Denom())
+}
+
+func ceilRat(value *big.Rat) *big.Int {
+	return new(big.Int).Neg(floorRat(new(big.Rat).Neg(value)))
+}
+
+// Round half away from zero
+func roundRat(value *big.Rat) *big.Int {
+	half := big.NewRat(1, 2)
+	if value.Sign() < 0 {
+		return ceilRat(new(big.Rat).Sub(value, half))
+	}
+	return floorRat(new(big.Rat).Add(value, half))
+}
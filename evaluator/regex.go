@@ -0,0 +1,159 @@
+package evaluator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/branislavlazic/bell/object"
+)
+
+// Builtins for regular expressions. Like the higher-order builtins,
+// they're registered in init, since a replacement can be a function.
+func init() {
+	builtins["re"] = &object.Builtin{Fn: reBuiltin}
+	builtins["re-match?"] = &object.Builtin{Fn: reMatchBuiltin}
+	builtins["re-find"] = &object.Builtin{Fn: reFindBuiltin}
+	builtins["re-find-all"] = &object.Builtin{Fn: reFindAllBuiltin}
+	builtins["re-replace"] = &object.Builtin{Fn: reReplaceBuiltin}
+	builtins["re-split"] = &object.Builtin{Fn: reSplitBuiltin}
+}
+
+func compileRegex(pattern string) (*regexp.Regexp, *object.RuntimeError) {
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, &object.RuntimeError{
+			Kind:  object.SyntaxErrorKind,
+			Error: fmt.Sprintf("Invalid regular expression: %s.", strings.TrimPrefix(err.Error(), "error parsing regexp: ")),
+		}
+	}
+	return regex, nil
+}
+
+// Give the regex, or compile a string into one.
+func toRegex(obj object.Object) (*regexp.Regexp, *object.RuntimeError) {
+	switch obj := obj.(type) {
+	case *object.Regex:
+		return obj.Value, nil
+	case *object.String:
+		return compileRegex(obj.Value)
+	default:
+		return nil, notApplicable(obj)
+	}
+}
+
+// Check whether a builtin function got the expected number of
+// arguments, starting with a regex and a string it's applied to.
+func regexArgs(args []object.Object, expected int) (*regexp.Regexp, string, *object.RuntimeError) {
+	if err := checkArgsCount(args, expected); err != nil {
+		return nil, "", err
+	}
+	regex, err := toRegex(args[0])
+	if err != nil {
+		return nil, "", err
+	}
+	str, ok := args[1].(*object.String)
+	if !ok {
+		return nil, "", notApplicable(args[1])
+	}
+	return regex, str.Value, nil
+}
+
+// Give a match at the indexes within the string. Without capture groups
+// it's the matched string, otherwise it's a list of the matched string
+// followed by the groups, where a group which didn't match is nil.
+func newMatch(str string, indexes []int) object.Object {
+	if len(indexes) == 2 {
+		return &object.String{Value: str[indexes[0]:indexes[1]]}
+	}
+	groups := make([]object.Object, len(indexes)/2)
+	for i := range groups {
+		start, end := indexes[2*i], indexes[2*i+1]
+		if start < 0 {
+			groups[i] = &object.Nil{}
+		} else {
+			groups[i] = &object.String{Value: str[start:end]}
+		}
+	}
+	return &object.List{Objects: groups}
+}
+
+func reBuiltin(args ...object.Object) object.Object {
+	if err := checkArgsCount(args, 1); err != nil {
+		return err
+	}
+	regex, err := toRegex(args[0])
+	if err != nil {
+		return err
+	}
+	return &object.Regex{Value: regex}
+}
+
+// Check whether the regex matches anywhere within the string.
+func reMatchBuiltin(args ...object.Object) object.Object {
+	regex, str, err := regexArgs(args, 2)
+	if err != nil {
+		return err
+	}
+	return &object.Boolean{Value: regex.MatchString(str)}
+}
+
+// Give the first match, or nil if there's none.
+func reFindBuiltin(args ...object.Object) object.Object {
+	regex, str, err := regexArgs(args, 2)
+	if err != nil {
+		return err
+	}
+	indexes := regex.FindStringSubmatchIndex(str)
+	if indexes == nil {
+		return &object.Nil{}
+	}
+	return newMatch(str, indexes)
+}
+
+// Give a list of all matches, or nil if there are none.
+func reFindAllBuiltin(args ...object.Object) object.Object {
+	regex, str, err := regexArgs(args, 2)
+	if err != nil {
+		return err
+	}
+	var matches []object.Object
+	for _, indexes := range regex.FindAllStringSubmatchIndex(str, -1) {
+		matches = append(matches, newMatch(str, indexes))
+	}
+	return newList(matches)
+}
+
+// Replace all matches. A replacement string can refer to capture groups,
+// e.g. $1 or ${name}, while a replacement function is called with each match
+// and its result is written in the display form.
+func reReplaceBuiltin(args ...object.Object) object.Object {
+	regex, str, err := regexArgs(args, 3)
+	if err != nil {
+		return err
+	}
+	if replacement, ok := args[2].(*object.String); ok {
+		return &object.String{Value: regex.ReplaceAllString(str, replacement.Value)}
+	}
+	var sb strings.Builder
+	last := 0
+	for _, indexes := range regex.FindAllStringSubmatchIndex(str, -1) {
+		result := Apply(args[2], newMatch(str, indexes))
+		if isError(result) {
+			return result
+		}
+		sb.WriteString(str[last:indexes[0]])
+		sb.WriteString(result.Inspect())
+		last = indexes[1]
+	}
+	sb.WriteString(str[last:])
+	return &object.String{Value: sb.String()}
+}
+
+func reSplitBuiltin(args ...object.Object) object.Object {
+	regex, str, err := regexArgs(args, 2)
+	if err != nil {
+		return err
+	}
+	return newStringList(regex.Split(str, -1))
+}
@@ -0,0 +1,194 @@
+package evaluator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/branislavlazic/bell/object"
+)
+
+// Maximum size of a string built by a builtin in bytes,
+// so that a huge string can't exhaust the memory.
+const maxStringSize = 1 << 26
+
+// Check whether a builtin function got the expected
+// number of arguments and all of them are strings.
+func stringArgs(args []object.Object, expected int) ([]string, *object.RuntimeError) {
+	if err := checkArgsCount(args, expected); err != nil {
+		return nil, err
+	}
+	values := make([]string, len(args))
+	for i, arg := range args {
+		str, ok := arg.(*object.String)
+		if !ok {
+			return nil, notApplicable(arg)
+		}
+		values[i] = str.Value
+	}
+	return values, nil
+}
+
+// Apply a function to a single string argument and give the resulting string.
+func mapString(args []object.Object, fn func(string) string) object.Object {
+	values, err := stringArgs(args, 1)
+	if err != nil {
+		return err
+	}
+	return &object.String{Value: fn(values[0])}
+}
+
+// Apply a predicate to two string arguments.
+func testStrings(args []object.Object, predicate func(string, string) bool) object.Object {
+	values, err := stringArgs(args, 2)
+	if err != nil {
+		return err
+	}
+	return &object.Boolean{Value: predicate(values[0], values[1])}
+}
+
+// Give a list of strings, or nil if there are none.
+func newStringList(values []string) object.Object {
+	objects := make([]object.Object, len(values))
+	for i, value := range values {
+		objects[i] = &object.String{Value: value}
+	}
+	return newList(objects)
+}
+
+// Give an index of the first occurrence of the substring
+// in characters, or -1 if the string doesn't contain it.
+func runeIndex(str string, substr string) int {
+	index := strings.Index(str, substr)
+	if index < 0 {
+		return -1
+	}
+	return len([]rune(str[:index]))
+}
+
+// Check whether the object is a position between characters of a string
+// of the given size, i.e. an index which can also be right after the end.
+func toStringPosition(obj object.Object, size int) (int, *object.RuntimeError) {
+	if n, ok := obj.(*object.Integer); ok && n.Value == int64(size) {
+		return size, nil
+	}
+	return toIndex(obj, size)
+}
+
+func substring(args []object.Object) object.Object {
+	if len(args) < 2 {
+		return checkArgsCount(args, 2)
+	} else if len(args) > 3 {
+		return checkArgsCount(args, 3)
+	}
+	str, ok := args[0].(*object.String)
+	if !ok {
+		return notApplicable(args[0])
+	}
+	runes := []rune(str.Value)
+	start, err := toStringPosition(args[1], len(runes))
+	if err != nil {
+		return err
+	}
+	// The end is optional and it isn't included
+	end := len(runes)
+	if len(args) == 3 {
+		end, err = toStringPosition(args[2], len(runes))
+		if err != nil {
+			return err
+		}
+	}
+	if start > end {
+		return &object.RuntimeError{
+			Kind:  object.IndexErrorKind,
+			Error: fmt.Sprintf("Start %d is greater than end %d.", start, end),
+		}
+	}
+	return &object.String{Value: string(runes[start:end])}
+}
+
+// Format the values with printf-style verbs, e.g. (format "%s: %.2f" "Sum" 3).
+// A verb can have flags, a width and a precision between '%' and its letter.
+func format(args ...object.Object) object.Object {
+	if len(args) == 0 {
+		return &object.RuntimeError{
+			Kind:  object.ArityErrorKind,
+			Error: "Insufficient number of arguments. Expected at least 1, got 0.",
+		}
+	}
+	str, ok := args[0].(*object.String)
+	if !ok {
+		return notApplicable(args[0])
+	}
+	layout, values := str.Value, args[1:]
+	var sb strings.Builder
+	used := 0
+	for i := 0; i < len(layout); i++ {
+		if layout[i] != '%' {
+			sb.WriteByte(layout[i])
+			continue
+		}
+		start := i
+		for i++; i < len(layout) && strings.IndexByte("+-# 0123456789.", layout[i]) >= 0; i++ {
+		}
+		if i == len(layout) {
+			return &object.RuntimeError{
+				Kind:  object.TypeErrorKind,
+				Error: fmt.Sprintf("Format string ends with an incomplete verb '%s'.", layout[start:]),
+			}
+		}
+		verb := layout[start : i+1]
+		if layout[i] == '%' {
+			sb.WriteByte('%')
+			continue
+		}
+		if used == len(values) {
+			return &object.RuntimeError{
+				Kind:  object.ArityErrorKind,
+				Error: fmt.Sprintf("Missing a value for verb '%s'.", verb),
+			}
+		}
+		formatted, err := formatValue(verb, values[used])
+		if err != nil {
+			return err
+		}
+		sb.WriteString(formatted)
+		used++
+	}
+	if used < len(values) {
+		return &object.RuntimeError{
+			Kind:  object.ArityErrorKind,
+			Error: fmt.Sprintf("Too many values for the format string. Expected %d, got %d.", used, len(values)),
+		}
+	}
+	return &object.String{Value: sb.String()}
+}
+
+// Format a value with a verb. Values are formatted by %s in their
+// display form and by %q in their readable form. Integers are formatted
+// by %d, %x, %X, %o and %b, while any number can be formatted as a float
+// by %f, %e, %E, %g and %G.
+func formatValue(verb string, value object.Object) (string, *object.RuntimeError) {
+	switch verb[len(verb)-1] {
+	case 's':
+		return fmt.Sprintf(verb, value.Inspect()), nil
+	case 'q':
+		return fmt.Sprintf(verb[:len(verb)-1]+"s", value.Repr()), nil
+	case 'd', 'x', 'X', 'o', 'b':
+		if isInteger(value) {
+			return fmt.Sprintf(verb, toBigInt(value)), nil
+		}
+	case 'f', 'e', 'E', 'g', 'G':
+		if isNumber(value) {
+			return fmt.Sprintf(verb, toFloat(value)), nil
+		}
+	default:
+		return "", &object.RuntimeError{
+			Kind:  object.TypeErrorKind,
+			Error: fmt.Sprintf("Unknown verb '%s'.", verb),
+		}
+	}
+	return "", &object.RuntimeError{
+		Kind:  object.TypeErrorKind,
+		Error: fmt.Sprintf("Verb '%s' cannot format a value of %s type.", verb, value.Type()),
+	}
+}
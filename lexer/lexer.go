@@ -1,6 +1,8 @@
 package lexer
 
 import (
+	"strings"
+
 	"github.com/branislavlazic/bell/token"
 )
 
@@ -9,21 +11,66 @@ type Lexer struct {
 	Position     int
 	readPosition int
 	ch           byte
+	fileName     string
+	line         int // Line of the current character
+	column       int // Column of the current character
+	// Type of the last token apart from line breaks and comments
+	lastType token.TokType
+	// Numbers of unclosed braces within each of the interpolations
+	// which are being read, the innermost one being the last
+	interpolations []int
+	// Emit comments as COMMENT tokens instead of skipping them,
+	// e.g. for tools which need to keep them
+	EmitComments bool
 }
 
 func New(input string) *Lexer {
-	lexer := &Lexer{input: input}
+	return NewWithFileName("", input)
+}
+
+// NewWithFileName creates a lexer which attaches
+// the given file name to positions of tokens.
+func NewWithFileName(fileName string, input string) *Lexer {
+	lexer := &Lexer{input: input, fileName: fileName, line: 1}
 	lexer.readChar()
 	return lexer
 }
 
 func (l *Lexer) NextToken() token.Token {
+	for {
+		l.skipWhitespace()
+		pos := token.Position{File: l.fileName, Line: l.line, Column: l.column}
+		var tok token.Token
+		if l.isCommentStart() {
+			tok = l.readComment()
+			if tok.Type == token.COMMENT && !l.EmitComments {
+				continue
+			}
+		} else {
+			tok = l.readToken()
+		}
+		tok.Pos = pos
+		if tok.Type != token.EOL && tok.Type != token.COMMENT {
+			l.lastType = tok.Type
+		}
+		return tok
+	}
+}
+
+func (l *Lexer) readToken() token.Token {
 	var tok token.Token
-	l.skipWhitespace()
 	switch l.ch {
 	case '+':
 		tok = newToken(token.ADD, l.ch)
 	case '-':
+		// A minus directly followed by a digit is a negative number, unless it's
+		// at the beginning of an expression, so that (-3 4) is a subtraction
+		if isDigit(l.peekChar()) && l.lastType != token.StartExpression {
+			l.readChar()
+			tok = l.readNumber()
+			tok.Literal = "-" + tok.Literal
+			return tok
+		}
 		tok = newToken(token.SUBTRACT, l.ch)
 	case '*':
 		tok = newToken(token.MULTIPLY, l.ch)
@@ -59,9 +106,40 @@ func (l *Lexer) NextToken() token.Token {
 		tok = newToken(token.StartParamList, l.ch)
 	case ']':
 		tok = newToken(token.EndParamList, l.ch)
+	case '{':
+		l.openBrace()
+		tok = newToken(token.StartMap, l.ch)
+	case '}':
+		// A brace which closes an interpolation continues the string
+		if n := len(l.interpolations); n > 0 && l.interpolations[n-1] == 0 {
+			l.interpolations = l.interpolations[:n-1]
+			tok = l.readStringPart(token.StringMiddle, token.StringEnd)
+		} else {
+			l.closeBrace()
+			tok = newToken(token.EndMap, l.ch)
+		}
+	case '#':
+		if l.peekChar() == '{' {
+			l.openBrace()
+			l.readChar()
+			tok = token.Token{Type: token.StartSet, Literal: "#{"}
+		} else if l.peekChar() == '"' {
+			l.readChar()
+			tok = token.Token{Type: token.REGEX, Literal: l.readRegex()}
+		} else {
+			tok = newToken(token.ILLEGAL, l.ch)
+		}
+	case ':':
+		// Keyword is a colon followed by an identifier, e.g. :name
+		if isLetter(l.peekChar()) {
+			l.readChar()
+			tok.Type = token.KEYWORD
+			tok.Literal = l.readIdentifier()
+			return tok
+		}
+		tok = newToken(token.ILLEGAL, l.ch)
 	case '"':
-		tok.Type = token.STRING
-		tok.Literal = l.readString()
+		tok = l.readStringPart(token.StringStart, token.STRING)
 	case '\n':
 		tok.Literal = ""
 		tok.Type = token.EOL
@@ -82,9 +160,7 @@ func (l *Lexer) NextToken() token.Token {
 			}
 			return tok
 		} else if isDigit(l.ch) {
-			tok.Literal = l.readNumber()
-			tok.Type = token.INT
-			return tok
+			return l.readNumber()
 		} else {
 			tok = newToken(token.ILLEGAL, l.ch)
 		}
@@ -95,11 +171,20 @@ func (l *Lexer) NextToken() token.Token {
 }
 
 func (l *Lexer) readChar() {
+	if l.ch == '\n' {
+		l.line++
+		l.column = 0
+	}
 	if l.readPosition >= len(l.input) {
 		l.ch = 0
 	} else {
 		l.ch = l.input[l.readPosition]
 	}
+	// Columns are counted in characters, so continuation
+	// bytes of multi-byte UTF-8 characters are skipped.
+	if l.ch&0xC0 != 0x80 {
+		l.column++
+	}
 	l.Position = l.readPosition
 	l.readPosition++
 }
@@ -111,12 +196,43 @@ func (l *Lexer) peekChar() byte {
 	return l.input[l.readPosition]
 }
 
-func (l *Lexer) readNumber() string {
+// Number is either an integer, a ratio of two integers, e.g. 3/4,
+// or a float with a fraction and/or an exponent, e.g. 3.14, 1e-9 or 2.5E3
+func (l *Lexer) readNumber() token.Token {
 	position := l.Position
+	tokType := token.TokType(token.INT)
+	l.readDigits()
+	if l.ch == '/' && isDigit(l.peekChar()) {
+		l.readChar()
+		l.readDigits()
+		return token.Token{Type: token.RATIO, Literal: l.input[position:l.Position]}
+	}
+	if l.ch == '.' && isDigit(l.peekChar()) {
+		tokType = token.FLOAT
+		l.readChar()
+		l.readDigits()
+	}
+	if l.ch == 'e' || l.ch == 'E' {
+		next := l.peekChar()
+		if (next == '+' || next == '-') && l.readPosition+1 < len(l.input) {
+			next = l.input[l.readPosition+1]
+			if isDigit(next) {
+				l.readChar()
+			}
+		}
+		if isDigit(next) {
+			tokType = token.FLOAT
+			l.readChar()
+			l.readDigits()
+		}
+	}
+	return token.Token{Type: tokType, Literal: l.input[position:l.Position]}
+}
+
+func (l *Lexer) readDigits() {
 	for isDigit(l.ch) {
 		l.readChar()
 	}
-	return l.input[position:l.Position]
 }
 
 // Identifier is a sequence of characters and
@@ -129,17 +245,93 @@ func (l *Lexer) readIdentifier() string {
 	return l.input[position:l.Position]
 }
 
-func (l *Lexer) readString() string {
-	var accumulator string
+// Read a string up to its end, or up to the start of an interpolated
+// expression, e.g. "Hello ${name}". A string with interpolations is split
+// into parts around the expressions, whose tokens are read in between.
+// The part gets the first type if an interpolation follows it.
+func (l *Lexer) readStringPart(interpolated token.TokType, last token.TokType) token.Token {
+	var sb strings.Builder
+	for {
+		l.readChar()
+		if l.ch == '"' || l.ch == 0 {
+			return token.Token{Type: last, Literal: sb.String()}
+		}
+		if l.ch == '$' && l.peekChar() == '{' {
+			l.readChar()
+			l.interpolations = append(l.interpolations, 0)
+			return token.Token{Type: interpolated, Literal: sb.String()}
+		}
+		sb.WriteString(l.withBreakLineCheck())
+	}
+}
+
+// Regular expression is written like a string prefixed with '#',
+// e.g. #"\d+". Backslashes are kept for the regular expression,
+// apart from the one which escapes a quote.
+func (l *Lexer) readRegex() string {
+	var sb strings.Builder
 	for {
 		l.readChar()
 		if l.ch == '"' || l.ch == 0 {
-			break
+			return sb.String()
+		}
+		if l.ch == '\\' && l.peekChar() != 0 {
+			l.readChar()
+			if l.ch != '"' {
+				sb.WriteByte('\\')
+			}
+		}
+		sb.WriteByte(l.ch)
+	}
+}
+
+// Count braces within an interpolation, so that
+// its end isn't mistaken for the end of a map.
+func (l *Lexer) openBrace() {
+	if n := len(l.interpolations); n > 0 {
+		l.interpolations[n-1]++
+	}
+}
+
+func (l *Lexer) closeBrace() {
+	if n := len(l.interpolations); n > 0 {
+		l.interpolations[n-1]--
+	}
+}
+
+func (l *Lexer) isCommentStart() bool {
+	return l.ch == ';' || l.ch == '#' && l.peekChar() == '|'
+}
+
+// Comment is either a line comment which starts with ';' and
+// ends with the line, or a block comment enclosed in '#|' and '|#'.
+// Block comments can be nested.
+func (l *Lexer) readComment() token.Token {
+	position := l.Position
+	if l.ch == ';' {
+		for l.ch != '\n' && l.ch != '\r' && l.ch != 0 {
+			l.readChar()
+		}
+		return token.Token{Type: token.COMMENT, Literal: l.input[position:l.Position]}
+	}
+	depth := 0
+	for {
+		switch {
+		case l.ch == 0:
+			// A block comment which isn't closed
+			return token.Token{Type: token.ILLEGAL, Literal: "#|"}
+		case l.ch == '#' && l.peekChar() == '|':
+			l.readChar()
+			depth++
+		case l.ch == '|' && l.peekChar() == '#':
+			l.readChar()
+			depth--
+		}
+		l.readChar()
+		if depth == 0 {
+			return token.Token{Type: token.COMMENT, Literal: l.input[position:l.Position]}
 		}
-		str := l.withBreakLineCheck()
-		accumulator = accumulator + str
 	}
-	return accumulator
 }
 
 func (l *Lexer) skipWhitespace() {
@@ -169,12 +361,14 @@ func newToken(tokenType token.TokType, ch byte) token.Token {
 
 func (l *Lexer) withBreakLineCheck() string {
 	if l.ch == '\\' {
-		if value, ok := breakLineChars[l.peekChar()]; ok {
+		if value, ok := escapeChars[l.peekChar()]; ok {
 			l.readChar()
 			return value
 		}
 	}
-	return string(l.ch)
+	// Bytes of multi-byte UTF-8 characters are kept as they are
+	return l.input[l.Position : l.Position+1]
 }
 
-var breakLineChars = map[byte]string{'n': "\n", 't': "\t", 'r': "\r"}
+// Characters which follow a backslash within a string
+var escapeChars = map[byte]string{'n': "\n", 't': "\t", 'r': "\r", '"': "\"", '\\': "\\", '$': "$"}
@@ -63,3 +63,368 @@ func TestNextToken(t *testing.T) {
 		}
 	}
 }
+
+func TestNextToken_Position(t *testing.T) {
+	input := `(let s "žut")
+  (size s)`
+	tests := []struct {
+		expectedType     token.TokType
+		expectedPosition token.Position
+	}{
+		{token.StartExpression, token.Position{File: "main.bell", Line: 1, Column: 1}},
+		{token.LET, token.Position{File: "main.bell", Line: 1, Column: 2}},
+		{token.IDENT, token.Position{File: "main.bell", Line: 1, Column: 6}},
+		{token.STRING, token.Position{File: "main.bell", Line: 1, Column: 8}},
+		{token.EndExpression, token.Position{File: "main.bell", Line: 1, Column: 13}},
+		{token.EOL, token.Position{File: "main.bell", Line: 1, Column: 14}},
+		{token.StartExpression, token.Position{File: "main.bell", Line: 2, Column: 3}},
+		{token.IDENT, token.Position{File: "main.bell", Line: 2, Column: 4}},
+		{token.IDENT, token.Position{File: "main.bell", Line: 2, Column: 9}},
+		{token.EndExpression, token.Position{File: "main.bell", Line: 2, Column: 10}},
+		{token.EOF, token.Position{File: "main.bell", Line: 2, Column: 11}},
+	}
+	l := NewWithFileName("main.bell", input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+		if tok.Pos != tt.expectedPosition {
+			t.Fatalf("tests[%d] - position wrong. expected=%s, got=%s", i, tt.expectedPosition, tok.Pos)
+		}
+	}
+}
+
+func TestNextToken_Comments(t *testing.T) {
+	input := `; Adds numbers
+(+ 1 2) ; inline
+#| block
+   #| nested |# comment |#
+(writeln "; not a comment #| either |#") ;`
+	tests := []struct {
+		expectedType    token.TokType
+		expectedLiteral string
+	}{
+		{token.EOL, ""},
+		{token.StartExpression, "("},
+		{token.ADD, "+"},
+		{token.INT, "1"},
+		{token.INT, "2"},
+		{token.EndExpression, ")"},
+		{token.EOL, ""},
+		{token.EOL, ""},
+		{token.StartExpression, "("},
+		{token.IDENT, "writeln"},
+		{token.STRING, "; not a comment #| either |#"},
+		{token.EndExpression, ")"},
+		{token.EOF, ""},
+	}
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextToken_EmitComments(t *testing.T) {
+	input := `#| block |# (- 3 1) ; line
+; at EOF`
+	tests := []struct {
+		expectedType     token.TokType
+		expectedLiteral  string
+		expectedPosition token.Position
+	}{
+		{token.COMMENT, "#| block |#", token.Position{Line: 1, Column: 1}},
+		{token.StartExpression, "(", token.Position{Line: 1, Column: 13}},
+		{token.SUBTRACT, "-", token.Position{Line: 1, Column: 14}},
+		{token.INT, "3", token.Position{Line: 1, Column: 16}},
+		{token.INT, "1", token.Position{Line: 1, Column: 18}},
+		{token.EndExpression, ")", token.Position{Line: 1, Column: 19}},
+		{token.COMMENT, "; line", token.Position{Line: 1, Column: 21}},
+		{token.EOL, "", token.Position{Line: 1, Column: 27}},
+		{token.COMMENT, "; at EOF", token.Position{Line: 2, Column: 1}},
+		{token.EOF, "", token.Position{Line: 2, Column: 9}},
+	}
+	l := New(input)
+	l.EmitComments = true
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+		if tok.Pos != tt.expectedPosition {
+			t.Fatalf("tests[%d] - position wrong. expected=%s, got=%s", i, tt.expectedPosition, tok.Pos)
+		}
+	}
+}
+
+func TestNextToken_UnclosedBlockComment(t *testing.T) {
+	input := `(+ 1 2) #| not closed`
+	l := New(input)
+	var tok token.Token
+	for i := 0; i < 6; i++ {
+		tok = l.NextToken()
+	}
+	if tok.Type != token.ILLEGAL {
+		t.Fatalf("test - tokentype wrong. expected=%q, got=%q", token.ILLEGAL, tok.Type)
+	}
+	if tok.Literal != "#|" {
+		t.Fatalf("test - literal wrong. expected=%q, got=%q", "#|", tok.Literal)
+	}
+	if tok = l.NextToken(); tok.Type != token.EOF {
+		t.Fatalf("test - tokentype wrong. expected=%q, got=%q", token.EOF, tok.Type)
+	}
+}
+
+func TestNextToken_Numbers(t *testing.T) {
+	input := `(+ 3.14 1e-9 -0.5 2.5E3 -7 4. 1e (- 2) 3/4 -1/2 5/)`
+	tests := []struct {
+		expectedType    token.TokType
+		expectedLiteral string
+	}{
+		{token.StartExpression, "("},
+		{token.ADD, "+"},
+		{token.FLOAT, "3.14"},
+		{token.FLOAT, "1e-9"},
+		{token.FLOAT, "-0.5"},
+		{token.FLOAT, "2.5E3"},
+		{token.INT, "-7"},
+		{token.INT, "4"},
+		{token.ILLEGAL, "."},
+		{token.INT, "1"},
+		{token.IDENT, "e"},
+		{token.StartExpression, "("},
+		{token.SUBTRACT, "-"},
+		{token.INT, "2"},
+		{token.EndExpression, ")"},
+		{token.RATIO, "3/4"},
+		{token.RATIO, "-1/2"},
+		{token.INT, "5"},
+		{token.DIVIDE, "/"},
+		{token.EndExpression, ")"},
+		{token.EOF, ""},
+	}
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextToken_NegativeNumbersAndSubtraction(t *testing.T) {
+	input := `(-3 4) (- 3 -1) (
+-2 ; comment
+-5)`
+	tests := []struct {
+		expectedType    token.TokType
+		expectedLiteral string
+	}{
+		{token.StartExpression, "("},
+		{token.SUBTRACT, "-"},
+		{token.INT, "3"},
+		{token.INT, "4"},
+		{token.EndExpression, ")"},
+		{token.StartExpression, "("},
+		{token.SUBTRACT, "-"},
+		{token.INT, "3"},
+		{token.INT, "-1"},
+		{token.EndExpression, ")"},
+		{token.StartExpression, "("},
+		{token.EOL, ""},
+		{token.SUBTRACT, "-"},
+		{token.INT, "2"},
+		{token.EOL, ""},
+		{token.INT, "-5"},
+		{token.EndExpression, ")"},
+		{token.EOF, ""},
+	}
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextToken_Map(t *testing.T) {
+	input := `{"a" 1 2 {}}`
+	tests := []struct {
+		expectedType    token.TokType
+		expectedLiteral string
+	}{
+		{token.StartMap, "{"},
+		{token.STRING, "a"},
+		{token.INT, "1"},
+		{token.INT, "2"},
+		{token.StartMap, "{"},
+		{token.EndMap, "}"},
+		{token.EndMap, "}"},
+		{token.EOF, ""},
+	}
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextToken_Keywords(t *testing.T) {
+	input := `(:name :is-ok? : :1)`
+	tests := []struct {
+		expectedType    token.TokType
+		expectedLiteral string
+	}{
+		{token.StartExpression, "("},
+		{token.KEYWORD, "name"},
+		{token.KEYWORD, "is-ok?"},
+		{token.ILLEGAL, ":"},
+		{token.ILLEGAL, ":"},
+		{token.INT, "1"},
+		{token.EndExpression, ")"},
+		{token.EOF, ""},
+	}
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextToken_Set(t *testing.T) {
+	input := `#{1 #{}} # {`
+	tests := []struct {
+		expectedType    token.TokType
+		expectedLiteral string
+	}{
+		{token.StartSet, "#{"},
+		{token.INT, "1"},
+		{token.StartSet, "#{"},
+		{token.EndMap, "}"},
+		{token.EndMap, "}"},
+		{token.ILLEGAL, "#"},
+		{token.StartMap, "{"},
+		{token.EOF, ""},
+	}
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextToken_StringEscapes(t *testing.T) {
+	input := `"a\"b" "c\\d\n" "\q"`
+	expectedLiterals := []string{"a\"b", "c\\d\n", "\\q"}
+	l := New(input)
+
+	for i, expectedLiteral := range expectedLiterals {
+		tok := l.NextToken()
+		if tok.Type != token.STRING {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q", i, token.STRING, tok.Type)
+		}
+		if tok.Literal != expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextToken_InterpolatedString(t *testing.T) {
+	input := `"a ${x} b ${{:c "${d}"}}" "\${e}"`
+	tests := []struct {
+		expectedType    token.TokType
+		expectedLiteral string
+	}{
+		{token.StringStart, "a "},
+		{token.IDENT, "x"},
+		{token.StringMiddle, " b "},
+		{token.StartMap, "{"},
+		{token.KEYWORD, "c"},
+		{token.StringStart, ""},
+		{token.IDENT, "d"},
+		{token.StringEnd, ""},
+		{token.EndMap, "}"},
+		{token.StringEnd, ""},
+		{token.STRING, "${e}"},
+		{token.EOF, ""},
+	}
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextToken_Regex(t *testing.T) {
+	input := `#"\d+\.\"" #"a\\" #"ž"`
+	expectedLiterals := []string{`\d+\."`, `a\\`, "ž"}
+	l := New(input)
+
+	for i, expectedLiteral := range expectedLiterals {
+		tok := l.NextToken()
+		if tok.Type != token.REGEX {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q", i, token.REGEX, tok.Type)
+		}
+		if tok.Literal != expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextToken_UnicodeString(t *testing.T) {
+	input := `"žuta ćuprija"`
+	l := New(input)
+
+	tok := l.NextToken()
+	if tok.Type != token.STRING {
+		t.Fatalf("test - tokentype wrong. expected=%q, got=%q", token.STRING, tok.Type)
+	}
+	if tok.Literal != "žuta ćuprija" {
+		t.Fatalf("test - literal wrong. expected=%q, got=%q", "žuta ćuprija", tok.Literal)
+	}
+}
@@ -0,0 +1,114 @@
+package object
+
+import (
+	"strconv"
+	"strings"
+)
+
+// HashKey identifies a value used as a key of a map.
+// Equal values have equal hash keys.
+type HashKey struct {
+	Type  ObjectType
+	Value string
+}
+
+// Hashable is a value which can be used as a key of a map.
+type Hashable interface {
+	Object
+	HashKey() HashKey
+}
+
+func (i *Integer) HashKey() HashKey {
+	return HashKey{Type: IntegerObj, Value: strconv.FormatInt(i.Value, 10)}
+}
+
+func (bi *BigInteger) HashKey() HashKey {
+	return HashKey{Type: IntegerObj, Value: bi.Value.String()}
+}
+
+func (b *Boolean) HashKey() HashKey {
+	return HashKey{Type: BooleanObj, Value: strconv.FormatBool(b.Value)}
+}
+
+func (s *String) HashKey() HashKey {
+	return HashKey{Type: StringObj, Value: s.Value}
+}
+
+func (k *Keyword) HashKey() HashKey {
+	return HashKey{Type: KeywordObj, Value: k.Name}
+}
+
+func (n *Nil) HashKey() HashKey {
+	return HashKey{Type: NilObj}
+}
+
+type MapPair struct {
+	Key   Object
+	Value Object
+}
+
+// Map associates keys with values. Keys are kept in
+// the order in which they were inserted.
+type Map struct {
+	Pairs map[HashKey]MapPair
+	Keys  []HashKey
+}
+
+func NewMap() *Map {
+	return &Map{Pairs: map[HashKey]MapPair{}}
+}
+
+func (m *Map) Type() ObjectType {
+	return MapObj
+}
+func (m *Map) Inspect() string {
+	return m.Repr()
+}
+func (m *Map) Repr() string {
+	var pairs []string
+	for _, hashKey := range m.Keys {
+		pair := m.Pairs[hashKey]
+		pairs = append(pairs, pair.Key.Repr()+" "+pair.Value.Repr())
+	}
+	return "{" + strings.Join(pairs, " ") + "}"
+}
+
+func (m *Map) Get(key Hashable) (Object, bool) {
+	pair, ok := m.Pairs[key.HashKey()]
+	return pair.Value, ok
+}
+
+// Set associates the key with the value. It modifies
+// the map, so it must be used only on new maps.
+func (m *Map) Set(key Hashable, value Object) {
+	hashKey := key.HashKey()
+	if _, ok := m.Pairs[hashKey]; !ok {
+		m.Keys = append(m.Keys, hashKey)
+	}
+	m.Pairs[hashKey] = MapPair{Key: key, Value: value}
+}
+
+// Delete removes the key. Like Set, it modifies the map.
+func (m *Map) Delete(key Hashable) {
+	hashKey := key.HashKey()
+	if _, ok := m.Pairs[hashKey]; !ok {
+		return
+	}
+	delete(m.Pairs, hashKey)
+	for i, k := range m.Keys {
+		if k == hashKey {
+			m.Keys = append(m.Keys[:i:i], m.Keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// Copy gives a new map with the same pairs.
+func (m *Map) Copy() *Map {
+	copied := &Map{Pairs: make(map[HashKey]MapPair, len(m.Pairs)), Keys: make([]HashKey, len(m.Keys))}
+	for hashKey, pair := range m.Pairs {
+		copied.Pairs[hashKey] = pair
+	}
+	copy(copied.Keys, m.Keys)
+	return copied
+}
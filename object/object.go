@@ -3,26 +3,43 @@ package object
 import (
 	"fmt"
 	"github.com/branislavlazic/bell/ast"
+	"github.com/branislavlazic/bell/token"
+	"math"
+	"math/big"
+	"strconv"
 	"strings"
+	"sync"
 )
 
 type ObjectType string
 
 const (
 	IntegerObj      = "INTEGER"
+	FloatObj        = "FLOAT"
+	RatioObj        = "RATIO"
 	BooleanObj      = "BOOLEAN"
 	StringObj       = "STRING"
+	KeywordObj      = "KEYWORD"
 	ListObj         = "LIST"
+	MapObj          = "MAP"
+	SetObj          = "SET"
+	RegexObj        = "REGEX"
 	FunctionObj     = "FUNCTION"
 	NilObj          = "NIL"
 	NoopObj         = "NOOP"
 	BuiltinObj      = "BUILTIN"
 	RuntimeErrorObj = "RUNTIME_ERROR"
+	ErrorObj        = "ERROR"
+	TailCallObj     = "TAIL_CALL"
+	RecurObj        = "RECUR"
 )
 
 type Object interface {
 	Type() ObjectType
+	// Inspect gives a display form of the value, e.g. for 'write'
 	Inspect() string
+	// Repr gives a form of the value which can be read back by the parser
+	Repr() string
 }
 
 type Integer struct {
@@ -35,6 +52,69 @@ func (i *Integer) Type() ObjectType {
 func (i *Integer) Inspect() string {
 	return fmt.Sprintf("%d", i.Value)
 }
+func (i *Integer) Repr() string {
+	return i.Inspect()
+}
+
+// BigInteger is an integer which doesn't fit into int64.
+// It has the same type as Integer.
+type BigInteger struct {
+	Value *big.Int
+}
+
+func (bi *BigInteger) Type() ObjectType {
+	return IntegerObj
+}
+func (bi *BigInteger) Inspect() string {
+	return bi.Value.String()
+}
+func (bi *BigInteger) Repr() string {
+	return bi.Inspect()
+}
+
+// Ratio is an exact fraction which isn't an integer.
+type Ratio struct {
+	Value *big.Rat
+}
+
+func (r *Ratio) Type() ObjectType {
+	return RatioObj
+}
+func (r *Ratio) Inspect() string {
+	return r.Value.RatString()
+}
+func (r *Ratio) Repr() string {
+	return r.Inspect()
+}
+
+type Float struct {
+	Value float64
+}
+
+func (f *Float) Type() ObjectType {
+	return FloatObj
+}
+func (f *Float) Inspect() string {
+	format := byte('f')
+	// Very small and very large numbers use the exponent notation
+	if abs := math.Abs(f.Value); abs != 0 && (abs < 1e-4 || abs >= 1e21) {
+		format = 'g'
+	}
+	str := strconv.FormatFloat(f.Value, format, -1, 64)
+	// Keep floats distinguishable from integers
+	if !strings.ContainsAny(str, ".eIN") {
+		str += ".0"
+	}
+	return str
+}
+func (f *Float) Repr() string {
+	// NaN and infinities can't be written as float
+	// literals, so they're converted from strings
+	if math.IsNaN(f.Value) || math.IsInf(f.Value, 0) {
+		return fmt.Sprintf("(float \"%s\")", f.Inspect())
+	}
+	return f.Inspect()
+}
 
 type Boolean struct {
 	Value bool
@@ -46,6 +126,9 @@ func (b *Boolean) Type() ObjectType {
 func (b *Boolean) Inspect() string {
 	return fmt.Sprintf("%t", b.Value)
 }
+func (b *Boolean) Repr() string {
+	return b.Inspect()
+}
 
 type String struct {
 	Value string
@@ -57,6 +140,44 @@ func (s *String) Type() ObjectType {
 func (s *String) Inspect() string {
 	return s.Value
 }
+func (s *String) Repr() string {
+	return quote(s.Value)
+}
+
+// Keyword is a name which stands for itself, e.g. :name.
+// Keywords are interned, so two keywords with the
+// same name are always the same object.
+type Keyword struct {
+	Name string
+}
+
+var (
+	keywords   = map[string]*Keyword{}
+	keywordsMu sync.Mutex
+)
+
+// InternKeyword gives the keyword with the name,
+// creating it the first time the name is used.
+func InternKeyword(name string) *Keyword {
+	keywordsMu.Lock()
+	defer keywordsMu.Unlock()
+	if keyword, ok := keywords[name]; ok {
+		return keyword
+	}
+	keyword := &Keyword{Name: name}
+	keywords[name] = keyword
+	return keyword
+}
+
+func (k *Keyword) Type() ObjectType {
+	return KeywordObj
+}
+func (k *Keyword) Inspect() string {
+	return ":" + k.Name
+}
+func (k *Keyword) Repr() string {
+	return k.Inspect()
+}
 
 type List struct {
 	Objects []Object
@@ -72,11 +193,23 @@ func (l *List) Inspect() string {
 	}
 	return strings.Join(exprsAsStrArr, " ")
 }
+func (l *List) Repr() string {
+	// Nil stands for an empty list
+	if len(l.Objects) == 0 {
+		return "nil"
+	}
+	var exprsAsStrArr []string
+	for _, obj := range l.Objects {
+		exprsAsStrArr = append(exprsAsStrArr, obj.Repr())
+	}
+	return fmt.Sprintf("(list %s)", strings.Join(exprsAsStrArr, " "))
+}
 
 type Function struct {
-	Identifier *ast.Identifier
+	Identifier *ast.Identifier // nil for anonymous functions
 	Params     []*ast.Identifier
 	Body       []ast.Expression
+	Env        *Environment // Environment in which the function was defined
 }
 
 func (f *Function) Type() ObjectType {
@@ -89,13 +222,50 @@ func (f *Function) Inspect() string {
 	}
 	joinedParams := strings.Join(params, " ")
 	if params != nil {
-		return fmt.Sprintf("(%s %s)", f.Identifier.String(), joinedParams)
+		return fmt.Sprintf("(%s %s)", f.Name(), joinedParams)
 	}
-	return fmt.Sprintf("(%s)", f.Identifier.String())
+	return fmt.Sprintf("(%s)", f.Name())
+}
+func (f *Function) Repr() string {
+	return f.Inspect()
 }
 
+func (f *Function) Name() string {
+	// Anonymous functions don't have an identifier
+	if f.Identifier == nil {
+		return "fn"
+	}
+	return f.Identifier.String()
+}
+
+type ErrorKind string
+
+// Kinds of runtime errors
+const (
+	TypeErrorKind       = "TYPE_ERROR"
+	ArityErrorKind      = "ARITY_ERROR"
+	UndefinedErrorKind  = "UNDEFINED_ERROR"
+	SyntaxErrorKind     = "SYNTAX_ERROR"
+	FileErrorKind       = "FILE_ERROR"
+	ThrownErrorKind     = "THROWN_ERROR"
+	ArithmeticErrorKind = "ARITHMETIC_ERROR"
+	IndexErrorKind      = "INDEX_ERROR"
+	InternalErrorKind   = "INTERNAL_ERROR"
+)
+
 type RuntimeError struct {
-	Error string
+	Error  string
+	Kind   ErrorKind
+	Value  Object         // Value passed to 'throw'
+	Pos    token.Position // Position of the expression which caused the error
+	Frames []Frame        // Functions the error passed through, the innermost first
+}
+
+// Frame is a function call which an error passed through.
+// Calls in a tail position replace the frame of their caller.
+type Frame struct {
+	Function string
+	CallPos  token.Position // Position of the call, unknown for calls made by builtins
 }
 
 func (re *RuntimeError) Type() ObjectType {
@@ -104,6 +274,57 @@ func (re *RuntimeError) Type() ObjectType {
 func (re *RuntimeError) Inspect() string {
 	return re.Error
 }
+func (re *RuntimeError) Repr() string {
+	return re.Inspect()
+}
+
+// Backtrace gives the error message followed by its trace.
+func (re *RuntimeError) Backtrace() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Error: %s", re.Error))
+	for _, line := range re.Trace() {
+		sb.WriteString(fmt.Sprintf("\n    %s", line))
+	}
+	return sb.String()
+}
+
+// Trace gives the functions the error passed through and the
+// positions within them. The last line is the position of the
+// outermost call.
+func (re *RuntimeError) Trace() []string {
+	var trace []string
+	pos := re.Pos
+	for _, frame := range re.Frames {
+		if pos.IsValid() {
+			trace = append(trace, fmt.Sprintf("at %s (%s)", frame.Function, pos))
+		} else {
+			trace = append(trace, fmt.Sprintf("at %s", frame.Function))
+		}
+		pos = frame.CallPos
+	}
+	if pos.IsValid() {
+		trace = append(trace, fmt.Sprintf("at %s", pos))
+	}
+	return trace
+}
+
+// Error is a runtime error intercepted by 'catch'. Unlike RuntimeError,
+// it's an ordinary value which doesn't interrupt the evaluation.
+type Error struct {
+	Message string
+	Kind    ErrorKind
+	Value   Object
+}
+
+func (e *Error) Type() ObjectType {
+	return ErrorObj
+}
+func (e *Error) Inspect() string {
+	return e.Message
+}
+func (e *Error) Repr() string {
+	return e.Inspect()
+}
 
 type Nil struct{}
 
@@ -113,6 +334,9 @@ func (n *Nil) Type() ObjectType {
 func (n *Nil) Inspect() string {
 	return "nil"
 }
+func (n *Nil) Repr() string {
+	return n.Inspect()
+}
 
 type Noop struct{}
 
@@ -122,6 +346,9 @@ func (n *Noop) Type() ObjectType {
 func (n *Noop) Inspect() string {
 	return ""
 }
+func (n *Noop) Repr() string {
+	return n.Inspect()
+}
 
 type BuiltinFunction func(args ...Object) Object
 
@@ -135,3 +362,73 @@ func (b *Builtin) Type() ObjectType {
 func (b *Builtin) Inspect() string {
 	return "builtin"
 }
+func (b *Builtin) Repr() string {
+	return b.Inspect()
+}
+
+// TailCall is a call of a function in a tail position which is yet to be
+// performed. It's used internally by the evaluator and never
+// ends up as a result of an evaluation.
+type TailCall struct {
+	Fn      *Function
+	Args    []Object
+	CallPos token.Position
+}
+
+func (tc *TailCall) Type() ObjectType {
+	return TailCallObj
+}
+func (tc *TailCall) Inspect() string {
+	return "tail call"
+}
+func (tc *TailCall) Repr() string {
+	return tc.Inspect()
+}
+
+// Recur carries new values of loop bindings to the
+// enclosing loop. Like TailCall, it's used only internally.
+type Recur struct {
+	Args []Object
+}
+
+func (r *Recur) Type() ObjectType {
+	return RecurObj
+}
+func (r *Recur) Inspect() string {
+	return "recur"
+}
+func (r *Recur) Repr() string {
+	return r.Inspect()
+}
+
+// Put the string into double quotes. Characters which
+// cannot appear within a string literal are escaped.
+func quote(str string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for i, ch := range str {
+		switch ch {
+		case '$':
+			// Otherwise it would start an interpolation
+			if strings.HasPrefix(str[i+1:], "{") {
+				sb.WriteString(`\$`)
+			} else {
+				sb.WriteRune(ch)
+			}
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\r':
+			sb.WriteString(`\r`)
+		default:
+			sb.WriteRune(ch)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
@@ -0,0 +1,39 @@
+package object
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Regex is a compiled regular expression.
+type Regex struct {
+	Value *regexp.Regexp
+}
+
+func (r *Regex) Type() ObjectType {
+	return RegexObj
+}
+func (r *Regex) Inspect() string {
+	return r.Repr()
+}
+
+// Repr gives the regex literal. Quotes within the
+// pattern are escaped, while other escapes are kept.
+func (r *Regex) Repr() string {
+	var sb strings.Builder
+	sb.WriteString("#\"")
+	pattern := r.Value.String()
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case pattern[i] == '\\' && i+1 < len(pattern):
+			sb.WriteString(pattern[i : i+2])
+			i++
+		case pattern[i] == '"':
+			sb.WriteString("\\\"")
+		default:
+			sb.WriteByte(pattern[i])
+		}
+	}
+	sb.WriteString("\"")
+	return sb.String()
+}
@@ -0,0 +1,68 @@
+package object
+
+import "strings"
+
+// Set is a collection of distinct values. Like map keys, the values
+// have to be hashable. They're kept in the order of insertion.
+type Set struct {
+	Elements map[HashKey]Hashable
+	Keys     []HashKey
+}
+
+func NewSet() *Set {
+	return &Set{Elements: map[HashKey]Hashable{}}
+}
+
+func (s *Set) Type() ObjectType {
+	return SetObj
+}
+func (s *Set) Inspect() string {
+	return s.Repr()
+}
+func (s *Set) Repr() string {
+	var elements []string
+	for _, hashKey := range s.Keys {
+		elements = append(elements, s.Elements[hashKey].Repr())
+	}
+	return "#{" + strings.Join(elements, " ") + "}"
+}
+
+func (s *Set) Contains(element Hashable) bool {
+	_, ok := s.Elements[element.HashKey()]
+	return ok
+}
+
+// Add inserts the element. It modifies the set,
+// so it must be used only on new sets.
+func (s *Set) Add(element Hashable) {
+	hashKey := element.HashKey()
+	if _, ok := s.Elements[hashKey]; !ok {
+		s.Keys = append(s.Keys, hashKey)
+		s.Elements[hashKey] = element
+	}
+}
+
+// Remove deletes the element. Like Add, it modifies the set.
+func (s *Set) Remove(element Hashable) {
+	hashKey := element.HashKey()
+	if _, ok := s.Elements[hashKey]; !ok {
+		return
+	}
+	delete(s.Elements, hashKey)
+	for i, k := range s.Keys {
+		if k == hashKey {
+			s.Keys = append(s.Keys[:i:i], s.Keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// Copy gives a new set with the same elements.
+func (s *Set) Copy() *Set {
+	copied := &Set{Elements: make(map[HashKey]Hashable, len(s.Elements)), Keys: make([]HashKey, len(s.Keys))}
+	for hashKey, element := range s.Elements {
+		copied.Elements[hashKey] = element
+	}
+	copy(copied.Keys, s.Keys)
+	return copied
+}
@@ -2,9 +2,13 @@ package parser
 
 import (
 	"fmt"
+	"math/big"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/branislavlazic/bell/ast"
+	"github.com/branislavlazic/bell/diagnostic"
 	"github.com/branislavlazic/bell/lexer"
 	"github.com/branislavlazic/bell/token"
 )
@@ -13,11 +17,19 @@ type Parser struct {
 	lxr       *lexer.Lexer
 	curToken  token.Token
 	peekToken token.Token
-	Errors    []string
+	Errors    []diagnostic.Diagnostic
+	// Parsed 'recur' expressions which are
+	// not yet matched with an enclosing 'loop'
+	pendingRecurs []*ast.RecurExpression
+	// Parsed 'catch' clauses which are not
+	// yet matched with an enclosing 'try'
+	pendingCatches []*ast.CatchClause
+	// Number of opened parentheses up to the current token
+	depth int
 }
 
 func New(l *lexer.Lexer) *Parser {
-	p := &Parser{lxr: l, Errors: []string{}}
+	p := &Parser{lxr: l, Errors: []diagnostic.Diagnostic{}}
 	p.nextToken()
 	p.nextToken()
 	return p
@@ -28,27 +40,58 @@ func (p *Parser) ParseProgram() *ast.Program {
 	program.Expressions = []ast.Expression{}
 	for p.curToken.Type != token.EOF {
 		var expr ast.Expression
+		errorsCount := len(p.Errors)
 		// Start by checking whether the current token is StartExpression.
 		// If so, start parsing an expression.
 		if p.curToken.Type == token.StartExpression {
 			expr = p.parseExpression()
+		} else if p.curToken.Type == token.ILLEGAL {
+			p.addError(p.curToken, fmt.Sprintf("Illegal character '%s' found.", p.curToken.Literal))
 		}
-		// If there are any errors after parsing an expression,
-		// break any further parsing.
-		if len(p.Errors) > 0 {
-			break
+		// Any 'recur' which wasn't matched with
+		// a 'loop' is used outside of a loop.
+		if len(p.pendingRecurs) > 0 {
+			p.addError(p.pendingRecurs[0].Token, recurPositionError)
+			p.pendingRecurs = nil
+		}
+		if len(p.pendingCatches) > 0 {
+			p.addError(p.pendingCatches[0].Token, catchPositionError)
+			p.pendingCatches = nil
 		}
-		if expr != nil {
+		if len(p.Errors) > errorsCount {
+			p.recover(errorsCount)
+		} else if expr != nil {
 			program.Expressions = append(program.Expressions, expr)
 		}
 		p.nextToken()
 	}
 	if len(program.Expressions) == 0 && len(p.Errors) == 0 {
-		p.Errors = append(p.Errors, "No expression given.")
+		p.Errors = append(p.Errors, diagnostic.Diagnostic{Message: "No expression given."})
 	}
 	return program
 }
 
+// Recover from an error within a top-level expression by skipping
+// to its closing parentheses, so that the following expressions can
+// be parsed as well. Errors which followed the first error within
+// the expression are likely caused by it, so they're dropped.
+func (p *Parser) recover(errorsCount int) {
+	p.Errors = p.Errors[:errorsCount+1]
+	for p.depth > 0 && p.curToken.Type != token.EOF {
+		p.nextToken()
+	}
+}
+
+// Append an error pointing to the token.
+func (p *Parser) addError(tok token.Token, msg string) {
+	p.Errors = append(p.Errors, diagnostic.New(tok, msg))
+}
+
+// Append an error pointing to the token, with an advice on how to fix it.
+func (p *Parser) addErrorWithHint(tok token.Token, msg string, hint string) {
+	p.Errors = append(p.Errors, diagnostic.New(tok, msg).WithHint(hint))
+}
+
 func (p *Parser) parseExpression() ast.Expression {
 	var expr ast.Expression
 	switch p.peekToken.Type {
@@ -116,10 +159,30 @@ func (p *Parser) parseExpression() ast.Expression {
 		expr = p.ensureStartExpression(func() ast.Expression {
 			return p.parseLetExpression()
 		})
+	case token.FN:
+		expr = p.ensureStartExpression(func() ast.Expression {
+			return p.parseFnExpression()
+		})
 	case token.IF:
 		expr = p.ensureStartExpression(func() ast.Expression {
 			return p.parseIfExpression()
 		})
+	case token.LOOP:
+		expr = p.ensureStartExpression(func() ast.Expression {
+			return p.parseLoopExpression()
+		})
+	case token.RECUR:
+		expr = p.ensureStartExpression(func() ast.Expression {
+			return p.parseRecurExpression()
+		})
+	case token.TRY:
+		expr = p.ensureStartExpression(func() ast.Expression {
+			return p.parseTryExpression()
+		})
+	case token.CATCH:
+		expr = p.ensureStartExpression(func() ast.Expression {
+			return p.parseCatchClause()
+		})
 	case token.LIST:
 		expr = p.ensureStartExpression(func() ast.Expression {
 			return p.parseOperationExpression()
@@ -128,30 +191,39 @@ func (p *Parser) parseExpression() ast.Expression {
 		expr = p.ensureStartExpression(func() ast.Expression {
 			return p.parseOpenExpression()
 		})
-	case token.STRING:
-		expr = p.parseStringLiteral()
-	case token.NIL:
-		expr = p.parseNil()
+	case token.STRING, token.StringStart, token.REGEX, token.NIL, token.BOOL,
+		token.INT, token.FLOAT, token.RATIO, token.StartMap, token.StartSet:
+		expr = p.parseLiteral()
+	case token.StringMiddle, token.StringEnd:
+		// The end of an interpolation within an unclosed expression
+		p.nextToken()
+		p.addError(p.curToken, "Illegal character '}' found.")
+	case token.KEYWORD:
+		expr = p.parseKeywordLiteral()
 	case token.IDENT:
 		expr = p.parseIdentifier()
-	case token.BOOL:
-		expr = p.parseBoolLiteral()
-	case token.INT:
-		expr = p.parseIntLiteral()
+	case token.EndMap:
+		p.nextToken()
+		p.addError(p.curToken, "Illegal character '}' found.")
 	case token.StartExpression:
+		// Two consecutive '(' mean that the result of
+		// the inner expression is called, e.g. ((make-adder 2) 3)
+		isCall := p.curToken.Type == token.StartExpression
+		startTok := p.curToken
 		p.nextToken()
 		expr = p.parseExpression()
+		if isCall && expr != nil {
+			expr = p.parseCallArguments(startTok, expr)
+		}
 	case token.EndExpression:
 		p.nextToken()
-		expr = p.parseExpression()
-	case token.EOL:
-		p.nextToken()
+		// Don't go past the end of a top-level expression
+		if p.depth == 0 {
+			break
+		}
 		expr = p.parseExpression()
 	case token.ILLEGAL:
-		p.Errors = append(
-			p.Errors,
-			fmt.Sprintf("Illegal character '%s' found at index %d.", p.peekToken.Literal, p.lxr.Position-1),
-		)
+		p.addError(p.peekToken, fmt.Sprintf("Illegal character '%s' found.", p.peekToken.Literal))
 		break
 	default:
 		break
@@ -169,9 +241,10 @@ func (p *Parser) parseOperationExpression() ast.Expression {
 	isNotOperation := tok.Type == token.NOT && leadingExpr != nil
 	if isNotOperation && p.peekToken.Type != token.EndExpression {
 		p.nextToken()
-		p.Errors = append(
-			p.Errors,
+		p.addErrorWithHint(
+			tok,
 			"'not' operation either contains more than one expression or lacks a closing parentheses.",
+			"Combine multiple expressions with 'and' or 'or', e.g. (not (and a b)).",
 		)
 		return nil
 	}
@@ -182,7 +255,7 @@ func (p *Parser) parseOperationExpression() ast.Expression {
 		return &ast.NotExpression{Token: tok, Expr: leadingExpr}
 	}
 	if leadingExpr == nil {
-		p.Errors = append(p.Errors, fmt.Sprintf("Missing at least one expression for operation '%s'.", tok.Literal))
+		p.addError(tok, fmt.Sprintf("Missing at least one expression for operation '%s'.", tok.Literal))
 		return nil
 	}
 	exprs = append(exprs, leadingExpr)
@@ -236,7 +309,7 @@ func (p *Parser) parseOperationExpression() ast.Expression {
 func (p *Parser) parseLetExpression() ast.Expression {
 	letTok := p.curToken
 	if p.peekToken.Type != token.IDENT {
-		p.Errors = append(p.Errors, "'let' should be followed by an identifier.")
+		p.addError(letTok, "'let' should be followed by an identifier.")
 		return nil
 	}
 	ident := p.parseIdentifier()
@@ -256,7 +329,7 @@ func (p *Parser) parseLetExpression() ast.Expression {
 		return nil
 	}
 	if exprs == nil {
-		p.Errors = append(p.Errors, fmt.Sprintf("Missing an expression for assignment."))
+		p.addError(letTok, "Missing an expression for assignment.")
 		return nil
 	}
 	// Check whether the expression is closed.
@@ -272,16 +345,38 @@ func (p *Parser) parseLetExpression() ast.Expression {
 	return &ast.LetExpression{Token: letTok, Identifier: ident.(*ast.Identifier), Exprs: exprs}
 }
 
+func (p *Parser) parseFnExpression() ast.Expression {
+	fnTok := p.curToken
+	if p.peekToken.Type != token.StartParamList {
+		p.addError(fnTok, "'fn' should be followed by a list of parameters.")
+		return nil
+	}
+	params, ok := p.parseParams()
+	if !ok {
+		return nil
+	}
+	body, ok := p.collectExpressions()
+	if !ok {
+		return nil
+	}
+	if body == nil {
+		p.addError(fnTok, "Missing a body for 'fn'.")
+		return nil
+	}
+	p.nextToken()
+	return &ast.FunctionLiteral{Token: fnTok, Params: params, Body: body}
+}
+
 func (p *Parser) parseIfExpression() ast.Expression {
 	ifTok := p.curToken
 	cond := p.parseExpression()
 	if cond == nil {
-		p.Errors = append(p.Errors, fmt.Sprintf("If expression is missing condition."))
+		p.addError(ifTok, "If expression is missing condition.")
 		return nil
 	}
 	expr := p.parseExpression()
 	if expr == nil {
-		p.Errors = append(p.Errors, fmt.Sprintf("If expression is missing then expression."))
+		p.addError(ifTok, "If expression is missing then expression.")
 		return nil
 	}
 	var elseExpr ast.Expression
@@ -290,10 +385,7 @@ func (p *Parser) parseIfExpression() ast.Expression {
 	}
 	if p.peekToken.Type != token.EndExpression && elseExpr != nil {
 		if !p.isPeekEOF() {
-			p.Errors = append(
-				p.Errors,
-				fmt.Sprintf("Illegal character '%s' found at index %d.", p.peekToken.Literal, p.lxr.Position-1),
-			)
+			p.addError(p.peekToken, fmt.Sprintf("Illegal character '%s' found.", p.peekToken.Literal))
 			return nil
 		}
 	}
@@ -301,11 +393,129 @@ func (p *Parser) parseIfExpression() ast.Expression {
 	return &ast.IfExpression{Token: ifTok, Condition: cond, ThenExpr: expr, ElseExpr: elseExpr}
 }
 
+const recurPositionError = "'recur' can only be used in a tail position of 'loop'."
+
+func (p *Parser) parseLoopExpression() ast.Expression {
+	loopTok := p.curToken
+	if p.peekToken.Type != token.StartParamList {
+		p.addError(loopTok, "'loop' should be followed by a list of bindings.")
+		return nil
+	}
+	// Count only 'recur' expressions within this loop
+	outerRecurs := p.pendingRecurs
+	p.pendingRecurs = nil
+	defer func() { p.pendingRecurs = outerRecurs }()
+	bindings, inits, ok := p.parseBindings()
+	if !ok {
+		return nil
+	}
+	body, ok := p.collectExpressions()
+	if !ok {
+		return nil
+	}
+	if body == nil {
+		p.addError(loopTok, "Missing a body for 'loop'.")
+		return nil
+	}
+	p.nextToken()
+	// Every 'recur' within the loop has to be in a tail position
+	tailRecurs := collectTailRecurs(body[len(body)-1], map[*ast.RecurExpression]bool{})
+	for _, recur := range p.pendingRecurs {
+		if !tailRecurs[recur] {
+			p.addError(recur.Token, recurPositionError)
+			return nil
+		}
+	}
+	return &ast.LoopExpression{Token: loopTok, Bindings: bindings, Inits: inits, Body: body}
+}
+
+func (p *Parser) parseRecurExpression() ast.Expression {
+	recurTok := p.curToken
+	exprs, ok := p.collectExpressions()
+	if !ok {
+		return nil
+	}
+	p.nextToken()
+	recur := &ast.RecurExpression{Token: recurTok, Exprs: exprs}
+	p.pendingRecurs = append(p.pendingRecurs, recur)
+	return recur
+}
+
+// Collect 'recur' expressions which are in a tail position of the expression.
+func collectTailRecurs(expr ast.Expression, recurs map[*ast.RecurExpression]bool) map[*ast.RecurExpression]bool {
+	switch e := expr.(type) {
+	case *ast.RecurExpression:
+		recurs[e] = true
+	case *ast.IfExpression:
+		collectTailRecurs(e.ThenExpr, recurs)
+		if e.ElseExpr != nil {
+			collectTailRecurs(e.ElseExpr, recurs)
+		}
+	}
+	return recurs
+}
+
+const catchPositionError = "'catch' can only be used as the last expression of 'try'."
+
+func (p *Parser) parseTryExpression() ast.Expression {
+	tryTok := p.curToken
+	// Count only 'catch' clauses within this 'try'
+	outerCatches := p.pendingCatches
+	p.pendingCatches = nil
+	defer func() { p.pendingCatches = outerCatches }()
+	exprs, ok := p.collectExpressions()
+	if !ok {
+		return nil
+	}
+	p.nextToken()
+	if len(exprs) == 0 {
+		p.addError(tryTok, "Missing an expression for 'try'.")
+		return nil
+	}
+	catch, ok := exprs[len(exprs)-1].(*ast.CatchClause)
+	if !ok {
+		p.addError(tryTok, "'try' should end with a 'catch' clause.")
+		return nil
+	}
+	if len(exprs) == 1 {
+		p.addError(tryTok, "Missing an expression for 'try'.")
+		return nil
+	}
+	// Only the last expression can be a 'catch' clause
+	for _, pending := range p.pendingCatches {
+		if pending != catch {
+			p.addError(pending.Token, catchPositionError)
+			return nil
+		}
+	}
+	return &ast.TryExpression{Token: tryTok, Body: exprs[:len(exprs)-1], Catch: catch}
+}
+
+func (p *Parser) parseCatchClause() ast.Expression {
+	catchTok := p.curToken
+	if p.peekToken.Type != token.IDENT {
+		p.addError(catchTok, "'catch' should be followed by an identifier.")
+		return nil
+	}
+	ident := p.parseIdentifier().(*ast.Identifier)
+	body, ok := p.collectExpressions()
+	if !ok {
+		return nil
+	}
+	if body == nil {
+		p.addError(catchTok, "Missing a body for 'catch'.")
+		return nil
+	}
+	p.nextToken()
+	catch := &ast.CatchClause{Token: catchTok, Identifier: ident, Body: body}
+	p.pendingCatches = append(p.pendingCatches, catch)
+	return catch
+}
+
 func (p *Parser) parseOpenExpression() *ast.OpenExpression {
 	openTok := p.curToken
 	expr := p.parseStringLiteral()
 	p.nextToken()
-	p.nextToken()
 	return &ast.OpenExpression{Token: openTok, Expr: expr}
 }
 
@@ -323,51 +533,208 @@ func (p *Parser) parseParams() ([]*ast.Identifier, bool) {
 		case token.EndParamList:
 			p.nextToken()
 		default:
-			p.Errors = append(
-				p.Errors,
-				fmt.Sprintf("Illegal character '%s' found at index %d.", p.peekToken.Literal, p.lxr.Position-1),
-			)
+			p.addError(p.peekToken, fmt.Sprintf("Illegal character '%s' found.", p.peekToken.Literal))
 			return nil, false
 		}
 	}
 	return params, true
 }
 
+// Parse pairs of identifiers and their initial values within '[]'
+func (p *Parser) parseBindings() ([]*ast.Identifier, []ast.Expression, bool) {
+	var bindings []*ast.Identifier
+	var inits []ast.Expression
+	p.nextToken()
+	for p.peekToken.Type != token.EndParamList {
+		if p.isPeekEOF() || p.isPeekIllegal() || p.isPeekOperator() {
+			return nil, nil, false
+		}
+		if p.peekToken.Type != token.IDENT {
+			p.addError(p.peekToken, fmt.Sprintf("Illegal character '%s' found.", p.peekToken.Literal))
+			return nil, nil, false
+		}
+		ident := p.parseIdentifier().(*ast.Identifier)
+		if p.isPeekEOF() || p.isPeekIllegal() || p.isPeekOperator() {
+			return nil, nil, false
+		}
+		init := p.parseExpression()
+		if init == nil {
+			p.addError(ident.Token, fmt.Sprintf("Missing an initial value for '%s'.", ident.Value))
+			return nil, nil, false
+		}
+		bindings = append(bindings, ident)
+		inits = append(inits, init)
+	}
+	p.nextToken()
+	return bindings, inits, true
+}
+
 func (p *Parser) parseIdentifier() ast.Expression {
 	// If an identifier is at the beginning of an
 	// expression, then the expression is treated
 	// as a function call.
 	if p.curToken.Type == token.StartExpression {
+		startTok := p.curToken
 		p.nextToken()
 		ident := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
-		var args []ast.Expression
-		for p.peekToken.Type != token.EndExpression {
-			if p.isPeekEOF() || p.isPeekIllegal() || p.isPeekOperator() {
-				break
-			}
-			args = append(args, p.parseExpression())
+		return p.parseCallArguments(startTok, ident)
+	}
+	p.nextToken()
+	return &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+}
+
+// Like an identifier, a literal at the beginning of an expression
+// is called, e.g. ("s" 1). Since a literal is never a function,
+// the evaluator reports a call with arguments.
+func (p *Parser) parseLiteral() ast.Expression {
+	startTok := p.curToken
+	var expr ast.Expression
+	switch p.peekToken.Type {
+	case token.STRING:
+		expr = p.parseStringLiteral()
+	case token.StringStart:
+		expr = p.parseInterpolatedString()
+	case token.REGEX:
+		expr = p.parseRegexLiteral()
+	case token.NIL:
+		expr = p.parseNil()
+	case token.BOOL:
+		expr = p.parseBoolLiteral()
+	case token.INT:
+		expr = p.parseIntLiteral()
+	case token.FLOAT:
+		expr = p.parseFloatLiteral()
+	case token.RATIO:
+		expr = p.parseRatioLiteral()
+	case token.StartMap:
+		expr = p.parseMapLiteral()
+	case token.StartSet:
+		expr = p.parseSetLiteral()
+	}
+	if expr == nil || startTok.Type != token.StartExpression {
+		return expr
+	}
+	return p.parseCallArguments(startTok, expr)
+}
+
+// Parse elements within '#{}'
+func (p *Parser) parseSetLiteral() ast.Expression {
+	p.nextToken()
+	setTok := p.curToken
+	exprs, ok := p.collectBracedExpressions()
+	if !ok {
+		return nil
+	}
+	return &ast.SetLiteral{Token: setTok, Elements: exprs}
+}
+
+// Collect expressions until the closing '}' of a map or a set.
+func (p *Parser) collectBracedExpressions() ([]ast.Expression, bool) {
+	var exprs []ast.Expression
+	for p.peekToken.Type != token.EndMap {
+		if p.isPeekEOF() || p.isPeekIllegal() || p.isPeekOperator() {
+			return nil, false
+		}
+		if p.peekToken.Type == token.EndExpression || p.peekToken.Type == token.EndParamList {
+			p.addError(p.peekToken, fmt.Sprintf("Illegal character '%s' found.", p.peekToken.Literal))
+			return nil, false
 		}
+		expr := p.parseRequiredExpression()
+		if expr == nil {
+			return nil, false
+		}
+		exprs = append(exprs, expr)
+	}
+	p.nextToken()
+	return exprs, true
+}
+
+func (p *Parser) parseKeywordLiteral() ast.Expression {
+	// A keyword at the beginning of an expression
+	// looks itself up in a map, e.g. (:name person)
+	if p.curToken.Type == token.StartExpression {
+		startTok := p.curToken
 		p.nextToken()
-		return &ast.CallFunction{Identifier: ident, Args: args}
+		keyword := &ast.KeywordLiteral{Token: p.curToken, Value: p.curToken.Literal}
+		return p.parseCallArguments(startTok, keyword)
 	}
 	p.nextToken()
-	return &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	return &ast.KeywordLiteral{Token: p.curToken, Value: p.curToken.Literal}
+}
+
+// Parse arguments following the callee until the end of the expression
+func (p *Parser) parseCallArguments(startTok token.Token, callee ast.Expression) ast.Expression {
+	var args []ast.Expression
+	for p.peekToken.Type != token.EndExpression {
+		if p.isPeekEOF() || p.isPeekIllegal() || p.isPeekOperator() {
+			break
+		}
+		arg := p.parseRequiredExpression()
+		if arg == nil {
+			return nil
+		}
+		args = append(args, arg)
+	}
+	p.nextToken()
+	return &ast.CallFunction{Token: startTok, Callee: callee, Args: args}
+}
+
+// Parse pairs of keys and values within '{}'
+func (p *Parser) parseMapLiteral() ast.Expression {
+	p.nextToken()
+	mapTok := p.curToken
+	exprs, ok := p.collectBracedExpressions()
+	if !ok {
+		return nil
+	}
+	if len(exprs)%2 != 0 {
+		p.addError(mapTok, "Map literal must contain an even number of expressions.")
+		return nil
+	}
+	mapLiteral := &ast.MapLiteral{Token: mapTok}
+	for i := 0; i < len(exprs); i += 2 {
+		mapLiteral.Keys = append(mapLiteral.Keys, exprs[i])
+		mapLiteral.Values = append(mapLiteral.Values, exprs[i+1])
+	}
+	return mapLiteral
 }
 
 func (p *Parser) parseIntLiteral() *ast.IntegerLiteral {
 	p.nextToken()
-	value, err := strconv.Atoi(p.curToken.Literal)
+	value, err := strconv.ParseInt(p.curToken.Literal, 10, 64)
+	if err == nil {
+		return &ast.IntegerLiteral{Token: p.curToken, Value: value}
+	}
+	bigValue, ok := new(big.Int).SetString(p.curToken.Literal, 10)
+	if !ok {
+		p.addError(p.curToken, "Failed to parse a value to integer.")
+	}
+	return &ast.IntegerLiteral{Token: p.curToken, Big: bigValue}
+}
+
+func (p *Parser) parseFloatLiteral() *ast.FloatLiteral {
+	p.nextToken()
+	value, err := strconv.ParseFloat(p.curToken.Literal, 64)
 	if err != nil {
-		p.Errors = append(p.Errors, "Failed to parse a value to integer.")
+		p.addError(p.curToken, "Failed to parse a value to float.")
 	}
-	return &ast.IntegerLiteral{Token: p.curToken, Value: int64(value)}
+	return &ast.FloatLiteral{Token: p.curToken, Value: value}
+}
+
+func (p *Parser) parseRatioLiteral() *ast.RatioLiteral {
+	p.nextToken()
+	value, ok := new(big.Rat).SetString(p.curToken.Literal)
+	if !ok {
+		p.addError(p.curToken, "Failed to parse a value to ratio.")
+	}
+	return &ast.RatioLiteral{Token: p.curToken, Value: value}
 }
 
 func (p *Parser) parseBoolLiteral() *ast.BooleanLiteral {
 	p.nextToken()
 	value, err := strconv.ParseBool(p.curToken.Literal)
 	if err != nil {
-		p.Errors = append(p.Errors, "Failed to parse a value to bool.")
+		p.addError(p.curToken, "Failed to parse a value to bool.")
 	}
 	return &ast.BooleanLiteral{Token: p.curToken, Value: value}
 }
@@ -375,12 +742,65 @@ func (p *Parser) parseBoolLiteral() *ast.BooleanLiteral {
 func (p *Parser) parseStringLiteral() *ast.StringLiteral {
 	p.nextToken()
 	if p.curToken.Type != token.STRING {
-		p.Errors = append(p.Errors, "Not a string.")
+		p.addError(p.curToken, "Not a string.")
 		return nil
 	}
 	return &ast.StringLiteral{Token: p.curToken, Value: p.curToken.Literal}
 }
 
+// String with interpolations, e.g. "Sum: ${(+ a b)}", is a concatenation
+// of its parts and the interpolated expressions, i.e. (+ "Sum: " (+ a b)).
+// The first part is always kept, so that the result is a string.
+func (p *Parser) parseInterpolatedString() ast.Expression {
+	p.nextToken()
+	strTok := p.curToken
+	exprs := []ast.Expression{&ast.StringLiteral{Token: strTok, Value: strTok.Literal}}
+	for p.curToken.Type != token.StringEnd {
+		if p.isPeekEOF() || p.isPeekIllegal() || p.isPeekOperator() {
+			return nil
+		}
+		switch p.peekToken.Type {
+		case token.StringMiddle, token.StringEnd:
+			p.addError(p.peekToken, "Missing an expression within '${}'.")
+			return nil
+		case token.EndExpression, token.EndParamList:
+			p.addError(p.peekToken, fmt.Sprintf("Illegal character '%s' found.", p.peekToken.Literal))
+			return nil
+		}
+		expr := p.parseRequiredExpression()
+		if expr == nil {
+			return nil
+		}
+		if p.isPeekEOF() {
+			return nil
+		}
+		if p.peekToken.Type != token.StringMiddle && p.peekToken.Type != token.StringEnd {
+			p.addErrorWithHint(
+				p.peekToken,
+				"Only a single expression can be interpolated within '${}'.",
+				"Check whether the interpolation is closed with '}'.",
+			)
+			return nil
+		}
+		exprs = append(exprs, expr)
+		p.nextToken()
+		if p.curToken.Literal != "" {
+			exprs = append(exprs, &ast.StringLiteral{Token: p.curToken, Value: p.curToken.Literal})
+		}
+	}
+	return &ast.AddExpression{Token: strTok, Exprs: exprs}
+}
+
+func (p *Parser) parseRegexLiteral() ast.Expression {
+	p.nextToken()
+	value, err := regexp.Compile(p.curToken.Literal)
+	if err != nil {
+		p.addError(p.curToken, fmt.Sprintf("Invalid regular expression: %s.", strings.TrimPrefix(err.Error(), "error parsing regexp: ")))
+		return nil
+	}
+	return &ast.RegexLiteral{Token: p.curToken, Value: value}
+}
+
 func (p *Parser) parseNil() *ast.NilExpression {
 	p.nextToken()
 	return &ast.NilExpression{Token: p.curToken}
@@ -393,19 +813,47 @@ func (p *Parser) collectExpressions() ([]ast.Expression, bool) {
 		if p.isPeekEOF() || p.isPeekIllegal() || p.isPeekOperator() {
 			return nil, false
 		}
-		exprs = append(exprs, p.parseExpression())
+		expr := p.parseRequiredExpression()
+		if expr == nil {
+			return nil, false
+		}
+		exprs = append(exprs, expr)
 	}
 	return exprs, true
 }
 
+// Parse an expression which has to follow, e.g. an argument. A token which
+// can't start an expression is reported, since it wouldn't be skipped.
+func (p *Parser) parseRequiredExpression() ast.Expression {
+	peekTok := p.peekToken
+	expr := p.parseExpression()
+	if expr == nil && p.peekToken == peekTok {
+		p.addError(p.peekToken, fmt.Sprintf("Illegal character '%s' found.", p.peekToken.Literal))
+	}
+	return expr
+}
+
 func (p *Parser) nextToken() {
 	p.curToken = p.peekToken
+	switch p.curToken.Type {
+	case token.StartExpression:
+		p.depth++
+	case token.EndExpression:
+		// Unmatched closing parentheses are ignored
+		if p.depth > 0 {
+			p.depth--
+		}
+	}
 	p.peekToken = p.lxr.NextToken()
+	// Line breaks and comments carry no meaning between expressions
+	for p.peekToken.Type == token.EOL || p.peekToken.Type == token.COMMENT {
+		p.peekToken = p.lxr.NextToken()
+	}
 }
 
 func (p *Parser) isPeekEOF() bool {
 	if p.peekToken.Type == token.EOF {
-		p.Errors = append(p.Errors, fmt.Sprintf("Unexpected EOF at index %d.", p.lxr.Position-1))
+		p.addErrorWithHint(p.peekToken, "Unexpected EOF.", "Check whether all opened parentheses are closed.")
 		return true
 	}
 	return false
@@ -413,16 +861,25 @@ func (p *Parser) isPeekEOF() bool {
 
 func (p *Parser) isPeekIllegal() bool {
 	if p.peekToken.Type == token.ILLEGAL {
-		p.Errors = append(p.Errors, fmt.Sprintf("Illegal character '%s' found at index %d.", p.peekToken.Literal, p.lxr.Position-1))
+		p.addError(p.peekToken, fmt.Sprintf("Illegal character '%s' found.", p.peekToken.Literal))
 		return true
 	}
 	return false
 }
 
 func (p *Parser) isPeekOperator() bool {
+	// Strings, keywords and regexes can have the same literal as an operator, e.g. "+"
+	switch p.peekToken.Type {
+	case token.STRING, token.KEYWORD, token.REGEX, token.StringStart, token.StringMiddle, token.StringEnd:
+		return false
+	}
 	for _, op := range token.OperatorLiterals {
 		if p.peekToken.Literal == op {
-			p.Errors = append(p.Errors, fmt.Sprintf("Illegal use of operator '%s' at index %d.", p.peekToken.Literal, p.lxr.Position-1))
+			p.addErrorWithHint(
+				p.peekToken,
+				fmt.Sprintf("Illegal use of operator '%s'.", p.peekToken.Literal),
+				fmt.Sprintf("'%s' can only be used at the start of an expression, e.g. (%s ...).", p.peekToken.Literal, p.peekToken.Literal),
+			)
 			return true
 		}
 	}
@@ -432,10 +889,7 @@ func (p *Parser) isPeekOperator() bool {
 func (p *Parser) isPeekEndExpression() bool {
 	if p.peekToken.Type != token.EndExpression {
 		if !p.isPeekEOF() {
-			p.Errors = append(
-				p.Errors,
-				fmt.Sprintf("Illegal character '%s' found at index %d.", p.peekToken.Literal, p.lxr.Position-1),
-			)
+			p.addError(p.peekToken, fmt.Sprintf("Illegal character '%s' found.", p.peekToken.Literal))
 			return false
 		}
 	}
@@ -444,10 +898,7 @@ func (p *Parser) isPeekEndExpression() bool {
 
 func (p *Parser) isCurrStartExpression() bool {
 	if p.curToken.Type != token.StartExpression {
-		p.Errors = append(
-			p.Errors,
-			fmt.Sprintf("Illegal character '%s' found at index %d. Expecting '('.", p.peekToken.Literal, p.lxr.Position-1),
-		)
+		p.addError(p.peekToken, fmt.Sprintf("Illegal character '%s' found. Expecting '('.", p.peekToken.Literal))
 		return false
 	}
 	return true
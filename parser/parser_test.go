@@ -22,12 +22,17 @@ func TestParser_ParseLiteral(t *testing.T) {
 	if len(p.Errors) != 0 {
 		t.Fatalf("test - error list should be empty. expected=%d, got=%d", 0, len(p.Errors))
 	}
-	intLiteralExpr := prog.Expressions[0].(*ast.IntegerLiteral)
+	// A literal in the head position is called without arguments
+	intCallExpr := prog.Expressions[0].(*ast.CallFunction)
+	if len(intCallExpr.Args) != 0 {
+		t.Fatalf("test - wrong number of arguments. expected=%d, got=%d", 0, len(intCallExpr.Args))
+	}
+	intLiteralExpr := intCallExpr.Callee.(*ast.IntegerLiteral)
 	if intLiteralExpr.Value != 2 {
 		t.Fatalf("test - wrong value of integer literal. expected=%d, got=%d", 2, intLiteralExpr.Value)
 	}
 
-	boolLiteralExpr := prog.Expressions[1].(*ast.BooleanLiteral)
+	boolLiteralExpr := prog.Expressions[1].(*ast.CallFunction).Callee.(*ast.BooleanLiteral)
 	if boolLiteralExpr.Value != true {
 		t.Fatalf("test - wrong value of boolean literal. expected=%t, got=%t", true, boolLiteralExpr.Value)
 	}
@@ -57,6 +62,274 @@ func TestParser_ParseNegativeLiteral(t *testing.T) {
 
 }
 
+func TestParser_ParseFloatLiteral(t *testing.T) {
+	input := `(+ 3.14 -0.5 1e-9)`
+	l := lexer.New(input)
+	p := New(l)
+	prog := p.ParseProgram()
+
+	if len(p.Errors) != 0 {
+		t.Fatalf("test - error list should be empty. expected=%d, got=%d", 0, len(p.Errors))
+	}
+	addExpr := prog.Expressions[0].(*ast.AddExpression)
+	expectedValues := []float64{3.14, -0.5, 1e-9}
+	for i, expectedValue := range expectedValues {
+		floatLit := addExpr.Exprs[i].(*ast.FloatLiteral)
+		if floatLit.Value != expectedValue {
+			t.Fatalf("test[%d] - wrong value of float literal. expected=%g, got=%g", i, expectedValue, floatLit.Value)
+		}
+	}
+}
+
+func TestParser_ParseRatioLiteral(t *testing.T) {
+	input := `(+ 3/4 -6/8)`
+	l := lexer.New(input)
+	p := New(l)
+	prog := p.ParseProgram()
+
+	if len(p.Errors) != 0 {
+		t.Fatalf("test - error list should be empty. expected=%d, got=%d", 0, len(p.Errors))
+	}
+	addExpr := prog.Expressions[0].(*ast.AddExpression)
+	expectedValues := []string{"3/4", "-3/4"}
+	for i, expectedValue := range expectedValues {
+		ratioLit := addExpr.Exprs[i].(*ast.RatioLiteral)
+		if ratioLit.Value.String() != expectedValue {
+			t.Fatalf("test[%d] - wrong value of ratio literal. expected=%s, got=%s", i, expectedValue, ratioLit.Value)
+		}
+	}
+}
+
+func TestParser_ParseZeroDenominator(t *testing.T) {
+	input := `(+ 1/0 1)`
+	l := lexer.New(input)
+	p := New(l)
+	p.ParseProgram()
+
+	if len(p.Errors) != 1 {
+		t.Fatalf("test - wrong number of errors. expected=%d, got=%d", 1, len(p.Errors))
+	}
+	if p.Errors[0].String() != "1:4: Failed to parse a value to ratio." {
+		t.Fatalf("test - wrong error. expected=%s, got=%s", "1:4: Failed to parse a value to ratio.", p.Errors[0])
+	}
+}
+
+func TestParser_ParseMapLiteral(t *testing.T) {
+	input := `(list {"a" 1 2 (+ 1 2)} {})`
+	l := lexer.New(input)
+	p := New(l)
+	prog := p.ParseProgram()
+
+	if len(p.Errors) != 0 {
+		t.Fatalf("test - error list should be empty. expected=%d, got=%d", 0, len(p.Errors))
+	}
+	listExpr := prog.Expressions[0].(*ast.ListExpression)
+	expectedMaps := []string{"{a 1 2 (+ 1 2)}", "{}"}
+	for i, expectedMap := range expectedMaps {
+		mapLit := listExpr.Exprs[i].(*ast.MapLiteral)
+		if mapLit.String() != expectedMap {
+			t.Fatalf("test[%d] - wrong map literal. expected=%s, got=%s", i, expectedMap, mapLit.String())
+		}
+	}
+}
+
+func TestParser_ParseMapLiteralWithoutValue(t *testing.T) {
+	input := `(let m {"a" 1 "b"})`
+	l := lexer.New(input)
+	p := New(l)
+	p.ParseProgram()
+
+	expected := "1:8: Map literal must contain an even number of expressions."
+	if len(p.Errors) != 1 {
+		t.Fatalf("test - wrong number of errors. expected=%d, got=%d", 1, len(p.Errors))
+	}
+	if p.Errors[0].String() != expected {
+		t.Fatalf("test - wrong error. expected=%s, got=%s", expected, p.Errors[0])
+	}
+}
+
+func TestParser_ParseMapLiteralWithIllegalCharacter(t *testing.T) {
+	input := `(writeln {1 [})`
+	l := lexer.New(input)
+	p := New(l)
+	p.ParseProgram()
+
+	expected := "1:13: Illegal character '[' found."
+	if len(p.Errors) != 1 {
+		t.Fatalf("test - wrong number of errors. expected=%d, got=%d", 1, len(p.Errors))
+	}
+	if p.Errors[0].String() != expected {
+		t.Fatalf("test - wrong error. expected=%s, got=%s", expected, p.Errors[0])
+	}
+}
+
+func TestParser_ParseSetLiteral(t *testing.T) {
+	input := `(list #{1 "a" (+ 1 2)} #{})`
+	l := lexer.New(input)
+	p := New(l)
+	prog := p.ParseProgram()
+
+	if len(p.Errors) != 0 {
+		t.Fatalf("test - error list should be empty. expected=%d, got=%d", 0, len(p.Errors))
+	}
+	listExpr := prog.Expressions[0].(*ast.ListExpression)
+	expectedSets := []string{"#{1 a (+ 1 2)}", "#{}"}
+	for i, expectedSet := range expectedSets {
+		setLit := listExpr.Exprs[i].(*ast.SetLiteral)
+		if setLit.String() != expectedSet {
+			t.Fatalf("test[%d] - wrong set literal. expected=%s, got=%s", i, expectedSet, setLit.String())
+		}
+	}
+}
+
+func TestParser_ParseInterpolatedString(t *testing.T) {
+	input := `(writeln "a ${x} b ${(+ 1 2)}")`
+	l := lexer.New(input)
+	p := New(l)
+	prog := p.ParseProgram()
+
+	if len(p.Errors) != 0 {
+		t.Fatalf("test - error list should be empty. expected=%d, got=%d", 0, len(p.Errors))
+	}
+	callExpr := prog.Expressions[0].(*ast.CallFunction)
+	addExpr := callExpr.Args[0].(*ast.AddExpression)
+	if addExpr.String() != "(+ a  x  b  (+ 1 2))" {
+		t.Fatalf("test - wrong interpolated string. expected=%s, got=%s", "(+ a  x  b  (+ 1 2))", addExpr.String())
+	}
+}
+
+func TestParser_ParseInterpolatedStringErrorPosition(t *testing.T) {
+	input := `(writeln "a ${}")`
+	l := lexer.New(input)
+	p := New(l)
+	p.ParseProgram()
+
+	if len(p.Errors) != 1 {
+		t.Fatalf("test - wrong number of errors. expected=%d, got=%d", 1, len(p.Errors))
+	}
+	if p.Errors[0].String() != "1:15: Missing an expression within '${}'." {
+		t.Fatalf("test - wrong error. expected=%s, got=%s", "1:15: Missing an expression within '${}'.", p.Errors[0].String())
+	}
+}
+
+func TestParser_ParseInvalidRegex(t *testing.T) {
+	input := `(re-find #"(a" "a")`
+	l := lexer.New(input)
+	p := New(l)
+	p.ParseProgram()
+
+	if len(p.Errors) != 1 {
+		t.Fatalf("test - wrong number of errors. expected=%d, got=%d", 1, len(p.Errors))
+	}
+	expected := "1:10: Invalid regular expression: missing closing ): `(a`."
+	if p.Errors[0].String() != expected {
+		t.Fatalf("test - wrong error. expected=%s, got=%s", expected, p.Errors[0].String())
+	}
+}
+
+func TestParser_ParseSetLiteralWithIllegalCharacter(t *testing.T) {
+	input := `(writeln #{1 [})`
+	l := lexer.New(input)
+	p := New(l)
+	p.ParseProgram()
+
+	expected := "1:14: Illegal character '[' found."
+	if len(p.Errors) != 1 {
+		t.Fatalf("test - wrong number of errors. expected=%d, got=%d", 1, len(p.Errors))
+	}
+	if p.Errors[0].String() != expected {
+		t.Fatalf("test - wrong error. expected=%s, got=%s", expected, p.Errors[0])
+	}
+}
+
+func TestParser_ParseInterpolatedStringWithIllegalCharacter(t *testing.T) {
+	input := `(writeln "a ${[}")`
+	l := lexer.New(input)
+	p := New(l)
+	p.ParseProgram()
+
+	expected := "1:15: Illegal character '[' found."
+	if len(p.Errors) != 1 {
+		t.Fatalf("test - wrong number of errors. expected=%d, got=%d", 1, len(p.Errors))
+	}
+	if p.Errors[0].String() != expected {
+		t.Fatalf("test - wrong error. expected=%s, got=%s", expected, p.Errors[0])
+	}
+}
+
+func TestParser_ParseOpenExpression(t *testing.T) {
+	input := `(open "stdlib/collection")
+	(writeln 5)`
+	l := lexer.New(input)
+	p := New(l)
+	prog := p.ParseProgram()
+
+	if len(p.Errors) != 0 {
+		t.Fatalf("test - error list should be empty. expected=%d, got=%d", 0, len(p.Errors))
+	}
+	if len(prog.Expressions) != 2 {
+		t.Fatalf("test - wrong number of expressions. expected=%d, got=%d", 2, len(prog.Expressions))
+	}
+	if _, ok := prog.Expressions[1].(*ast.CallFunction); !ok {
+		t.Fatalf("test - expression following open should be a call. got=%s", prog.Expressions[1].String())
+	}
+}
+
+func TestParser_ParseKeywordsWithOperatorNames(t *testing.T) {
+	input := `(writeln :if :fn :list :not)`
+	l := lexer.New(input)
+	p := New(l)
+	prog := p.ParseProgram()
+
+	if len(p.Errors) != 0 {
+		t.Fatalf("test - error list should be empty. expected=%d, got=%d", 0, len(p.Errors))
+	}
+	callExpr := prog.Expressions[0].(*ast.CallFunction)
+	expectedNames := []string{"if", "fn", "list", "not"}
+	for i, expectedName := range expectedNames {
+		keyword := callExpr.Args[i].(*ast.KeywordLiteral)
+		if keyword.Value != expectedName {
+			t.Fatalf("test[%d] - wrong keyword. expected=%s, got=%s", i, expectedName, keyword.Value)
+		}
+	}
+}
+
+func TestParser_ParseKeywordCall(t *testing.T) {
+	input := `(:name person :unknown)`
+	l := lexer.New(input)
+	p := New(l)
+	prog := p.ParseProgram()
+
+	if len(p.Errors) != 0 {
+		t.Fatalf("test - error list should be empty. expected=%d, got=%d", 0, len(p.Errors))
+	}
+	callExpr := prog.Expressions[0].(*ast.CallFunction)
+	keyword := callExpr.Callee.(*ast.KeywordLiteral)
+	if keyword.Value != "name" {
+		t.Fatalf("test - wrong keyword. expected=%s, got=%s", "name", keyword.Value)
+	}
+	if len(callExpr.Args) != 2 {
+		t.Fatalf("test - wrong number of arguments. expected=%d, got=%d", 2, len(callExpr.Args))
+	}
+	if callExpr.Args[1].String() != ":unknown" {
+		t.Fatalf("test - wrong argument. expected=%s, got=%s", ":unknown", callExpr.Args[1].String())
+	}
+}
+
+func TestParser_ParseStringsWithOperatorLiterals(t *testing.T) {
+	input := `(replace "a-b" "-" "+") (list :list "if")`
+	l := lexer.New(input)
+	p := New(l)
+	prog := p.ParseProgram()
+
+	if len(p.Errors) != 0 {
+		t.Fatalf("test - error list should be empty. expected=%d, got=%d", 0, len(p.Errors))
+	}
+	if len(prog.Expressions) != 2 {
+		t.Fatalf("test - wrong number of expressions. expected=%d, got=%d", 2, len(prog.Expressions))
+	}
+}
+
 func TestParser_ParseTwoPlusExpression(t *testing.T) {
 	input := `(+ 2 3)
 	(- 7 9)`
@@ -157,3 +430,179 @@ func TestParser_LogicalOperations(t *testing.T) {
 		t.Fatalf("test - wrong value for boolean literal. expected=%t, got=%t", false, notEq.Exprs[1].(*ast.BooleanLiteral).Value)
 	}
 }
+
+func TestParser_ParseFunctionLiteral(t *testing.T) {
+	input := `(fn [a b] (+ a b))`
+	l := lexer.New(input)
+	p := New(l)
+	prog := p.ParseProgram()
+
+	if len(prog.Expressions) != 1 {
+		t.Fatalf("test - wrong number of expressions. expected=%d, got=%d", 1, len(prog.Expressions))
+	}
+	if len(p.Errors) != 0 {
+		t.Fatalf("test - error list should be empty. expected=%d, got=%d", 0, len(p.Errors))
+	}
+	fnLit := prog.Expressions[0].(*ast.FunctionLiteral)
+	if fnLit.Token.Type != token.FN {
+		t.Fatalf("test - wrong token type for function literal. expected=%s, got=%s", token.FN, fnLit.Token.Type)
+	}
+	if len(fnLit.Params) != 2 {
+		t.Fatalf("test - wrong number of parameters. expected=%d, got=%d", 2, len(fnLit.Params))
+	}
+	if fnLit.Params[0].Value != "a" || fnLit.Params[1].Value != "b" {
+		t.Fatalf("test - wrong parameters. expected=%s, got=%s", "a b", fnLit.Params[0].Value+" "+fnLit.Params[1].Value)
+	}
+	if _, ok := fnLit.Body[0].(*ast.AddExpression); !ok {
+		t.Fatalf("test - wrong body expression. expected=%s, got=%T", "*ast.AddExpression", fnLit.Body[0])
+	}
+}
+
+func TestParser_ParseCallOfExpression(t *testing.T) {
+	input := `((make-adder 2) 3)`
+	l := lexer.New(input)
+	p := New(l)
+	prog := p.ParseProgram()
+
+	if len(prog.Expressions) != 1 {
+		t.Fatalf("test - wrong number of expressions. expected=%d, got=%d", 1, len(prog.Expressions))
+	}
+	if len(p.Errors) != 0 {
+		t.Fatalf("test - error list should be empty. expected=%d, got=%d", 0, len(p.Errors))
+	}
+	call := prog.Expressions[0].(*ast.CallFunction)
+	innerCall := call.Callee.(*ast.CallFunction)
+	if innerCall.Callee.(*ast.Identifier).Value != "make-adder" {
+		t.Fatalf("test - wrong callee. expected=%s, got=%s", "make-adder", innerCall.Callee.String())
+	}
+	if innerCall.Args[0].(*ast.IntegerLiteral).Value != 2 {
+		t.Fatalf("test - wrong value for integer literal. expected=%d, got=%d", 2, innerCall.Args[0].(*ast.IntegerLiteral).Value)
+	}
+	if len(call.Args) != 1 {
+		t.Fatalf("test - wrong number of arguments. expected=%d, got=%d", 1, len(call.Args))
+	}
+	if call.Args[0].(*ast.IntegerLiteral).Value != 3 {
+		t.Fatalf("test - wrong value for integer literal. expected=%d, got=%d", 3, call.Args[0].(*ast.IntegerLiteral).Value)
+	}
+}
+
+func TestParser_ParseLoopExpression(t *testing.T) {
+	input := `(loop [i 0 acc 1] (if (> i 5) acc (recur (+ i 1) (* acc i))))`
+	l := lexer.New(input)
+	p := New(l)
+	prog := p.ParseProgram()
+
+	if len(prog.Expressions) != 1 {
+		t.Fatalf("test - wrong number of expressions. expected=%d, got=%d", 1, len(prog.Expressions))
+	}
+	if len(p.Errors) != 0 {
+		t.Fatalf("test - error list should be empty. expected=%d, got=%d", 0, len(p.Errors))
+	}
+	loopExpr := prog.Expressions[0].(*ast.LoopExpression)
+	if len(loopExpr.Bindings) != 2 {
+		t.Fatalf("test - wrong number of bindings. expected=%d, got=%d", 2, len(loopExpr.Bindings))
+	}
+	if loopExpr.Bindings[1].Value != "acc" {
+		t.Fatalf("test - wrong binding. expected=%s, got=%s", "acc", loopExpr.Bindings[1].Value)
+	}
+	if loopExpr.Inits[1].(*ast.IntegerLiteral).Value != 1 {
+		t.Fatalf("test - wrong initial value. expected=%d, got=%d", 1, loopExpr.Inits[1].(*ast.IntegerLiteral).Value)
+	}
+	recurExpr := loopExpr.Body[0].(*ast.IfExpression).ElseExpr.(*ast.RecurExpression)
+	if len(recurExpr.Exprs) != 2 {
+		t.Fatalf("test - wrong number of recur arguments. expected=%d, got=%d", 2, len(recurExpr.Exprs))
+	}
+}
+
+func TestParser_ParseRecurOutsideOfTailPosition(t *testing.T) {
+	input := `(loop [i 0] (+ 1 (recur i)))`
+	l := lexer.New(input)
+	p := New(l)
+	p.ParseProgram()
+
+	if len(p.Errors) != 1 {
+		t.Fatalf("test - wrong number of errors. expected=%d, got=%d", 1, len(p.Errors))
+	}
+	if p.Errors[0].String() != "1:19: 'recur' can only be used in a tail position of 'loop'." {
+		t.Fatalf("test - wrong error. expected=%s, got=%s", "1:19: 'recur' can only be used in a tail position of 'loop'.", p.Errors[0])
+	}
+}
+
+func TestParser_ParseTryExpression(t *testing.T) {
+	input := `(try (head 1) (+ 1 2) (catch e (error-message e)))`
+	l := lexer.New(input)
+	p := New(l)
+	prog := p.ParseProgram()
+
+	if len(prog.Expressions) != 1 {
+		t.Fatalf("test - wrong number of expressions. expected=%d, got=%d", 1, len(prog.Expressions))
+	}
+	if len(p.Errors) != 0 {
+		t.Fatalf("test - error list should be empty. expected=%d, got=%d", 0, len(p.Errors))
+	}
+	tryExpr := prog.Expressions[0].(*ast.TryExpression)
+	if len(tryExpr.Body) != 2 {
+		t.Fatalf("test - wrong number of body expressions. expected=%d, got=%d", 2, len(tryExpr.Body))
+	}
+	if tryExpr.Catch.Identifier.Value != "e" {
+		t.Fatalf("test - wrong catch identifier. expected=%s, got=%s", "e", tryExpr.Catch.Identifier.Value)
+	}
+	if len(tryExpr.Catch.Body) != 1 {
+		t.Fatalf("test - wrong number of catch body expressions. expected=%d, got=%d", 1, len(tryExpr.Catch.Body))
+	}
+}
+
+func TestParser_ReportErrorsOfAllExpressions(t *testing.T) {
+	input := `(let x (+ 1 2))
+(+ x &)
+(writeln x)
+(if)
+(let y [a] (* a +))
+(loop [i] i)
+(writeln (- x 1)`
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+
+	expectedErrors := []string{
+		"2:6: Illegal character '&' found.",
+		"4:2: If expression is missing condition.",
+		"5:17: Illegal use of operator '+'.",
+		"6:8: Missing an initial value for 'i'.",
+		"7:17: Unexpected EOF.",
+	}
+	if len(p.Errors) != len(expectedErrors) {
+		t.Fatalf("test - wrong number of errors. expected=%d, got=%d", len(expectedErrors), len(p.Errors))
+	}
+	for i, expectedError := range expectedErrors {
+		if p.Errors[i].String() != expectedError {
+			t.Fatalf("test[%d] - wrong error. expected=%s, got=%s", i, expectedError, p.Errors[i])
+		}
+	}
+	if len(program.Expressions) != 2 {
+		t.Fatalf("test - wrong number of expressions. expected=%d, got=%d", 2, len(program.Expressions))
+	}
+}
+
+func TestParser_ReportSingleErrorPerExpression(t *testing.T) {
+	input := `(try (recur 1) (catch e e) (catch e e))
+(+ 1 2)`
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+
+	expectedErrors := []string{
+		"1:17: 'catch' can only be used as the last expression of 'try'.",
+	}
+	if len(p.Errors) != len(expectedErrors) {
+		t.Fatalf("test - wrong number of errors. expected=%d, got=%d", len(expectedErrors), len(p.Errors))
+	}
+	for i, expectedError := range expectedErrors {
+		if p.Errors[i].String() != expectedError {
+			t.Fatalf("test[%d] - wrong error. expected=%s, got=%s", i, expectedError, p.Errors[i])
+		}
+	}
+	if len(program.Expressions) != 1 {
+		t.Fatalf("test - wrong number of expressions. expected=%d, got=%d", 1, len(program.Expressions))
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/branislavlazic/bell/diagnostic"
 	"github.com/branislavlazic/bell/evaluator"
 	"github.com/branislavlazic/bell/lexer"
 	"github.com/branislavlazic/bell/object"
@@ -17,18 +18,27 @@ import (
 var godogs int
 
 var evalResult string
-var parserErrors []string
+var evalBacktrace string
+var evalDiagnostic string
+var parserErrors []diagnostic.Diagnostic
 
 func program(prog *godog.DocString) error {
 	env := object.NewEnvironment()
 	l := lexer.New(prog.Content)
 	p := parser.New(l)
 	program := p.ParseProgram()
+	renderer := diagnostic.NewRenderer(false)
+	renderer.AddSource("", prog.Content)
 	if len(p.Errors) > 0 {
 		parserErrors = p.Errors
+		evalDiagnostic = renderer.Render(p.Errors[0])
 	} else {
 		evalRes := evaluator.Eval(program, env)
 		evalResult = evalRes.Inspect()
+		if runtimeErr, ok := evalRes.(*object.RuntimeError); ok {
+			evalBacktrace = runtimeErr.Backtrace()
+			evalDiagnostic = renderer.RenderRuntimeError(runtimeErr)
+		}
 	}
 	return nil
 }
@@ -40,18 +50,32 @@ func resultIs(res *godog.DocString) error {
 	return nil
 }
 
+func backtraceIs(res *godog.DocString) error {
+	if res.Content != evalBacktrace {
+		return fmt.Errorf("incorrect backtrace. expected=%s, got=%s", res.Content, evalBacktrace)
+	}
+	return nil
+}
+
 func errorIs(res *godog.DocString) error {
-	if res.Content != parserErrors[0] {
+	if res.Content != parserErrors[0].String() {
 		return fmt.Errorf("incorrect result. expected=%s, got=%s", res.Content, parserErrors[0])
 	}
 	return nil
 }
 
+func diagnosticIs(res *godog.DocString) error {
+	if res.Content != evalDiagnostic {
+		return fmt.Errorf("incorrect diagnostic. expected=\n%s\ngot=\n%s", res.Content, evalDiagnostic)
+	}
+	return nil
+}
+
 func InitializeTestSuite(ctx *godog.TestSuiteContext) {
 	ctx.BeforeSuite(func() {
 		godogs = 0
 		evalResult = ""
-		parserErrors = []string{}
+		parserErrors = []diagnostic.Diagnostic{}
 	})
 }
 
@@ -59,11 +83,15 @@ func InitializeScenario(ctx *godog.ScenarioContext) {
 	ctx.BeforeScenario(func(*godog.Scenario) {
 		godogs = 0
 		evalResult = ""
-		parserErrors = []string{}
+		evalBacktrace = ""
+		evalDiagnostic = ""
+		parserErrors = []diagnostic.Diagnostic{}
 	})
 	ctx.Step(`^the program$`, program)
 	ctx.Step(`^the result is$`, resultIs)
 	ctx.Step(`^the error is$`, errorIs)
+	ctx.Step(`^the backtrace is$`, backtraceIs)
+	ctx.Step(`^the diagnostic is$`, diagnosticIs)
 }
 
 var opts = godog.Options{
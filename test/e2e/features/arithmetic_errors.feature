@@ -0,0 +1,111 @@
+Feature: Arithmetic errors
+  Scenario: It should not divide an integer by zero
+    Given the program
+      """
+      (/ 1 0)
+      """
+    Then the backtrace is
+      """
+      Error: Division by zero.
+          at 1:2
+      """
+
+  Scenario: It should not divide a float by zero
+    Given the program
+      """
+      (/ 1.5 0.0)
+      """
+    Then the result is
+      """
+      Division by zero.
+      """
+
+  Scenario: It should not divide a big integer by zero
+    Given the program
+      """
+      (/ (^ 2 100) 0)
+      """
+    Then the result is
+      """
+      Division by zero.
+      """
+
+  Scenario: It should not divide a ratio by zero
+    Given the program
+      """
+      (list (try (/ 1/2 0) (catch e (error-message e))) (try (ratio 1 0) (catch e (error-message e))))
+      """
+    Then the result is
+      """
+      Division by zero. Division by zero.
+      """
+
+  Scenario: It should not give a remainder of division by zero
+    Given the program
+      """
+      (list (try (% 5 0) (catch e (error-message e))) (try (% 1/2 0) (catch e (error-message e))))
+      """
+    Then the result is
+      """
+      Modulo by zero. Modulo by zero.
+      """
+
+  Scenario: It should not raise an integer to a negative power
+    Given the program
+      """
+      (^ 2 -1)
+      """
+    Then the result is
+      """
+      Integers cannot be raised to a negative power -1. Use a ratio or a float instead.
+      """
+
+  Scenario: It should raise a ratio and a float to a negative power
+    Given the program
+      """
+      (list (^ 2/3 -1) (^ 1/2 -2) (^ 2.0 -1))
+      """
+    Then the result is
+      """
+      3/2 4 0.5
+      """
+
+  Scenario: It should catch an arithmetic error
+    Given the program
+      """
+      (try (% 10 0) (catch e (error-kind e)))
+      """
+    Then the result is
+      """
+      ARITHMETIC_ERROR
+      """
+
+  Scenario: It should not raise an integer to a huge power
+    Given the program
+      """
+      (^ 2 10000000000)
+      """
+    Then the result is
+      """
+      Result of the power is too large.
+      """
+
+  Scenario: It should not raise a ratio to a huge power
+    Given the program
+      """
+      (try (^ 3/2 -10000000000) (catch e (error-kind e)))
+      """
+    Then the result is
+      """
+      ARITHMETIC_ERROR
+      """
+
+  Scenario: It should raise 1 and -1 to any power
+    Given the program
+      """
+      (list (^ 1 10000000000) (^ -1 10000000001) (^ 2 64))
+      """
+    Then the result is
+      """
+      1 -1 18446744073709551616
+      """
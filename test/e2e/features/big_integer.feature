@@ -0,0 +1,117 @@
+Feature: Arbitrary-precision integers
+  Scenario: It should promote a product which overflows
+    Given the program
+      """
+      (* 9223372036854775807 2)
+      """
+    Then the result is
+      """
+      18446744073709551614
+      """
+
+  Scenario: It should promote a sum which overflows
+    Given the program
+      """
+      (+ 9223372036854775807 1)
+      """
+    Then the result is
+      """
+      9223372036854775808
+      """
+
+  Scenario: It should promote a difference which overflows
+    Given the program
+      """
+      (- -9223372036854775808 1)
+      """
+    Then the result is
+      """
+      -9223372036854775809
+      """
+
+  Scenario: It should raise an integer to a large power exactly
+    Given the program
+      """
+      (^ 2 100)
+      """
+    Then the result is
+      """
+      1267650600228229401496703205376
+      """
+
+  Scenario: It should give a remainder of a big integer
+    Given the program
+      """
+      (% (^ 2 100) 7)
+      """
+    Then the result is
+      """
+      2
+      """
+
+  Scenario: It should demote a result which fits into a small integer
+    Given the program
+      """
+      (/ (* 9223372036854775807 4) 4)
+      """
+    Then the result is
+      """
+      9223372036854775807
+      """
+
+  Scenario: It should evaluate big integer literals
+    Given the program
+      """
+      (+ 100000000000000000000 1)
+      """
+    Then the result is
+      """
+      100000000000000000001
+      """
+
+  Scenario: It should negate the smallest integer
+    Given the program
+      """
+      (- -9223372036854775808)
+      """
+    Then the result is
+      """
+      9223372036854775808
+      """
+
+  Scenario: It should compare small and big integers
+    Given the program
+      """
+      (list
+        (= (- (+ 9223372036854775807 1) 1) 9223372036854775807)
+        (< 1 (^ 2 64) (^ 2 65))
+        (> (^ 2 64) 1.5)
+        (not= (^ 2 64) 0))
+      """
+    Then the result is
+      """
+      true true true true
+      """
+
+  Scenario: It should calculate a factorial of a large number
+    Given the program
+      """
+      (let factorial [n]
+        (loop [i n acc 1]
+          (if (= i 0) acc (recur (- i 1) (* acc i)))))
+      (factorial 25)
+      """
+    Then the result is
+      """
+      15511210043330985984000000
+      """
+
+  Scenario: It should convert big integers
+    Given the program
+      """
+      (list (float (^ 2 64)) (int "123456789012345678901234567890") (int 1e20))
+      """
+    Then the result is
+      """
+      18446744073709552000.0 123456789012345678901234567890 100000000000000000000
+      """
@@ -0,0 +1,94 @@
+Feature: Calling expressions
+  Scenario: It should call a function returned from a function call
+    Given the program
+      """
+      (let make-adder [n] (fn [x] (+ x n)))
+      ((make-adder 2) 3)
+      """
+    Then the result is
+      """
+      5
+      """
+
+  Scenario: It should call a function taken from a list
+    Given the program
+      """
+      (let fns (list (fn [x] (* x 10)) (fn [x] (+ x 1))))
+      ((head fns) 1)
+      """
+    Then the result is
+      """
+      10
+      """
+
+  Scenario: It should call an anonymous function immediately
+    Given the program
+      """
+      ((fn [a b] (* a b)) 6 7)
+      """
+    Then the result is
+      """
+      42
+      """
+
+  Scenario: It should call a builtin function returned from a function call
+    Given the program
+      """
+      (let pick [] head)
+      ((pick) (list 3 4))
+      """
+    Then the result is
+      """
+      3
+      """
+
+  Scenario: It should call a function returned from a nested function call
+    Given the program
+      """
+      (let curry [a] (fn [b] (fn [c] (+ a b c))))
+      (((curry 1) 2) 3)
+      """
+    Then the result is
+      """
+      6
+      """
+
+  Scenario: It should return an error when the callee is not a function
+    Given the program
+      """
+      ((+ 1 2) 3)
+      """
+    Then the result is
+      """
+      Expression (+ 1 2) is not callable. It evaluates to INTEGER type.
+      """
+
+  Scenario: It should return an error when the callee is a literal
+    Given the program
+      """
+      ("s" 1)
+      """
+    Then the result is
+      """
+      Expression "s" is not callable. It evaluates to STRING type.
+      """
+
+  Scenario: It should evaluate a literal in the head position without arguments to itself
+    Given the program
+      """
+      (+ (5) 1)
+      """
+    Then the result is
+      """
+      6
+      """
+
+  Scenario: It should return an error when the callee is an undefined function
+    Given the program
+      """
+      ((missing 1) 2)
+      """
+    Then the result is
+      """
+      Function missing is undefined
+      """
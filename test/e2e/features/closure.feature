@@ -0,0 +1,65 @@
+Feature: Closures
+  Scenario: It should capture a parameter of the outer function
+    Given the program
+      """
+      (let make-adder [n] (let _ [x] (+ x n)))
+      (let add2 (make-adder 2))
+      (add2 3)
+      """
+    Then the result is
+      """
+      5
+      """
+
+  Scenario: It should keep captured environments independent of each other
+    Given the program
+      """
+      (let make-adder [n] (let _ [x] (+ x n)))
+      (let add2 (make-adder 2))
+      (let add10 (make-adder 10))
+      (+ (add2 1) (add10 1))
+      """
+    Then the result is
+      """
+      14
+      """
+
+  Scenario: It should support partial application
+    Given the program
+      """
+      (let partial [func a] (let _ [b] (func a b)))
+      (let multiply [a b] (* a b))
+      (let triple (partial multiply 3))
+      (triple 7)
+      """
+    Then the result is
+      """
+      21
+      """
+
+  Scenario: It should support counters which carry their state in a closure
+    Given the program
+      """
+      (let counter [n] (list n (let _ [] (counter (+ n 1)))))
+      (let first (counter 0))
+      (let next (head (tail first)))
+      (let second (next))
+      (head second)
+      """
+    Then the result is
+      """
+      1
+      """
+
+  Scenario: It should resolve free variables in the defining scope instead of the calling scope
+    Given the program
+      """
+      (let x 1)
+      (let get-x [] x)
+      (let shadow [x] (get-x))
+      (shadow 5)
+      """
+    Then the result is
+      """
+      1
+      """
@@ -0,0 +1,48 @@
+Feature: Comments
+  Scenario: It should ignore line comments
+    Given the program
+      """
+      ; Doubles the number
+      (let double [x]
+          (* x 2)) ; multiplication
+      (double 4) ;
+      """
+    Then the result is
+      """
+      8
+      """
+
+  Scenario: It should ignore block comments
+    Given the program
+      """
+      #| Doubles the number.
+         #| Block comments can be nested. |#
+      |#
+      (let double [x] (* x #| inline |# 2))
+      (double 4)
+      """
+    Then the result is
+      """
+      8
+      """
+
+  Scenario: It should keep comment characters in strings
+    Given the program
+      """
+      (+ "; not a comment" " #| either |#")
+      """
+    Then the result is
+      """
+      ; not a comment #| either |#
+      """
+
+  Scenario: It should not allow a block comment which isn't closed
+    Given the program
+      """
+      (+ 1 2)
+      #| not closed
+      """
+    Then the error is
+      """
+      2:1: Illegal character '#|' found.
+      """
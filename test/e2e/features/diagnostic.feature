@@ -0,0 +1,48 @@
+Feature: Diagnostics
+  Scenario: It should point to a missing closing parentheses
+    Given the program
+      """
+      (+ 6 3
+      """
+    Then the diagnostic is
+      """
+      error: Unexpected EOF.
+       --> 1:7
+        |
+      1 | (+ 6 3
+        |       ^
+        = hint: Check whether all opened parentheses are closed.
+      """
+
+  Scenario: It should point to an operator used as an expression
+    Given the program
+      """
+      (let x 3)
+      (+ x 4 not)
+      """
+    Then the diagnostic is
+      """
+      error: Illegal use of operator 'not'.
+       --> 2:8
+        |
+      2 | (+ x 4 not)
+        |        ^~~
+        = hint: 'not' can only be used at the start of an expression, e.g. (not ...).
+      """
+
+  Scenario: It should point to an expression which caused a runtime error
+    Given the program
+      """
+      (let first [x] (head x))
+      (first 1)
+      """
+    Then the diagnostic is
+      """
+      error: Function is not applicable for INTEGER type.
+       --> 1:16
+        |
+      1 | (let first [x] (head x))
+        |                ^
+          at first (1:16)
+          at 2:1
+      """
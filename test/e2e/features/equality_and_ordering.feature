@@ -0,0 +1,132 @@
+Feature: Equality and ordering
+  Scenario: It should compare strings for equality
+    Given the program
+      """
+      (list (= "a" "a") (= "a" "b") (not= "a" "b") (= "žut" "žut" "žut"))
+      """
+    Then the result is
+      """
+      true false true true
+      """
+
+  Scenario: It should compare lists structurally
+    Given the program
+      """
+      (list
+        (= (list 1 (list "a" :b)) (list 1 (list "a" :b)))
+        (= (list 1 2) (list 2 1))
+        (= (list 1 2) (list 1 2 3)))
+      """
+    Then the result is
+      """
+      true false false
+      """
+
+  Scenario: It should compare numbers of different types within lists
+    Given the program
+      """
+      (= (list 1 1/2) (list 1.0 0.5))
+      """
+    Then the result is
+      """
+      true
+      """
+
+  Scenario: It should compare maps regardless of the order of keys
+    Given the program
+      """
+      (list
+        (= {:a 1 :b (list 1 2)} {:b (list 1 2) :a 1})
+        (= {:a 1} {:a 2})
+        (= {:a 1} {:a 1 :b 2}))
+      """
+    Then the result is
+      """
+      true false false
+      """
+
+  Scenario: It should compare sets regardless of the order of values
+    Given the program
+      """
+      (list (= #{1 2 3} #{3 2 1}) (= #{1 2} #{1 3}))
+      """
+    Then the result is
+      """
+      true false
+      """
+
+  Scenario: It should not treat values of different types as equal
+    Given the program
+      """
+      (list (= "1" 1) (= (list 1) 1) (= {} #{}) (not= true "true"))
+      """
+    Then the result is
+      """
+      false false false true
+      """
+
+  Scenario: It should compare functions by identity
+    Given the program
+      """
+      (let f [x] x)
+      (let g [x] x)
+      (list (= f f) (= f g) (= head head))
+      """
+    Then the result is
+      """
+      true false true
+      """
+
+  Scenario: It should order strings lexicographically
+    Given the program
+      """
+      (list (< "apple" "banana") (< "b" "apple") (<= "a" "a" "b") (> "ab" "a") (< "Z" "a"))
+      """
+    Then the result is
+      """
+      true false true true true
+      """
+
+  Scenario: It should order keywords by their names
+    Given the program
+      """
+      (< :a :b :c)
+      """
+    Then the result is
+      """
+      true
+      """
+
+  Scenario: It should order lists element by element
+    Given the program
+      """
+      (list
+        (< (list 1 2) (list 1 3))
+        (< (list 1 2) (list 1 2 0))
+        (>= (list "b") (list "a" "z"))
+        (< (list (list 1)) (list (list 2))))
+      """
+    Then the result is
+      """
+      true true true true
+      """
+
+  Scenario: It should not order values of different types
+    Given the program
+      """
+      (< "a" 1)
+      """
+    Then the result is
+      """
+      Operation (< a 1) cannot be performed for types: STRING and INTEGER
+      """
+
+  Scenario: It should not order lists with elements of different types
+    Given the program
+      """
+      (< (list 1 2) (list 1 "a"))
+      """
+    Then the result is
+      """
+      Operation (< (list 1 2) (list 1 a)) cannot be performed for types: LIST and LIST
+      """
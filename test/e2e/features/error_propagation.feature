@@ -0,0 +1,98 @@
+Feature: Error propagation
+  Scenario: It should stop a program at the first error
+    Given the program
+      """
+      (let x (head 1))
+      (+ 1 2)
+      """
+    Then the result is
+      """
+      Function is not applicable for INTEGER type.
+      """
+
+  Scenario: It should propagate an error through arguments of a function
+    Given the program
+      """
+      (let sq [x] (* x x))
+      (sq (+ 1 true))
+      """
+    Then the result is
+      """
+      Operation (+ 1 true) cannot be performed for types: INTEGER and BOOLEAN
+      """
+
+  Scenario: It should propagate an error from a condition of "if"
+    Given the program
+      """
+      (if (> 1 nil) 1 2)
+      """
+    Then the result is
+      """
+      Operation (> 1 nil) cannot be performed for types: INTEGER and NIL
+      """
+
+  Scenario: It should propagate an error from an element of a list
+    Given the program
+      """
+      (list 1 (throw "Broken element") 3)
+      """
+    Then the result is
+      """
+      Broken element
+      """
+
+  Scenario: It should record functions which an error passed through
+    Given the program
+      """
+      (let validate [x] (if (< x 0) (throw "Negative number") x))
+      (let double [x] (* 2 (validate x)))
+      (let run [] (+ 1 (double (- 3))))
+      (run)
+      """
+    Then the backtrace is
+      """
+      Error: Negative number
+          at validate (1:31)
+          at double (2:22)
+          at run (3:18)
+          at 4:1
+      """
+
+  Scenario: It should record anonymous functions which an error passed through
+    Given the program
+      """
+      ((fn [x] (+ x (head x))) 1)
+      """
+    Then the backtrace is
+      """
+      Error: Function is not applicable for INTEGER type.
+          at fn (1:15)
+          at 1:1
+      """
+
+  Scenario: It should record a position of an error outside of functions
+    Given the program
+      """
+      (let x 1)
+      (writeln x)
+      (+ x (head x))
+      """
+    Then the backtrace is
+      """
+      Error: Function is not applicable for INTEGER type.
+          at 3:6
+      """
+
+  Scenario: It should record a position of the original call for calls in a tail position
+    Given the program
+      """
+      (let fail [] (throw "Failed"))
+      (let forward [] (fail))
+      (forward)
+      """
+    Then the backtrace is
+      """
+      Error: Failed
+          at fail (1:14)
+          at 3:1
+      """
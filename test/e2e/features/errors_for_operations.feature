@@ -6,7 +6,7 @@ Feature: Errors for operations
       """
     Then the error is
       """
-      Unexpected EOF at index 6.
+      1:7: Unexpected EOF.
       """
 
   Scenario: It should return "No expression given" when opening parentheses is missing
@@ -26,7 +26,7 @@ Feature: Errors for operations
       """
     Then the error is
       """
-      Illegal use of operator '+' at index 7.
+      1:8: Illegal use of operator '+'.
       """
 
 
@@ -37,7 +37,7 @@ Feature: Errors for operations
       """
     Then the error is
       """
-      Illegal character '&' found at index 1.
+      1:2: Illegal character '&' found.
       """
 
 
@@ -48,7 +48,7 @@ Feature: Errors for operations
       """
     Then the error is
       """
-      'not' operation either contains more than one expression or lacks a closing parentheses.
+      1:2: 'not' operation either contains more than one expression or lacks a closing parentheses.
       """
 
 
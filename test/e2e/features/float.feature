@@ -0,0 +1,140 @@
+Feature: Floating-point numbers
+  Scenario: It should evaluate float literals
+    Given the program
+      """
+      (list 3.14 1e-9 -0.5 2.5e3 1e21)
+      """
+    Then the result is
+      """
+      3.14 1e-09 -0.5 2500.0 1e+21
+      """
+
+  Scenario: It should add floats
+    Given the program
+      """
+      (+ 0.5 0.25)
+      """
+    Then the result is
+      """
+      0.75
+      """
+
+  Scenario: It should promote integers to floats
+    Given the program
+      """
+      (* 2 1.5 3)
+      """
+    Then the result is
+      """
+      9.0
+      """
+
+  Scenario: It should divide floats
+    Given the program
+      """
+      (/ 7 2.0)
+      """
+    Then the result is
+      """
+      3.5
+      """
+
+  Scenario: It should give a remainder of float division
+    Given the program
+      """
+      (% 7.5 2)
+      """
+    Then the result is
+      """
+      1.5
+      """
+
+  Scenario: It should raise a float to a power
+    Given the program
+      """
+      (^ 4 0.5)
+      """
+    Then the result is
+      """
+      2.0
+      """
+
+  Scenario: It should negate a float
+    Given the program
+      """
+      (- 2.5)
+      """
+    Then the result is
+      """
+      -2.5
+      """
+
+  Scenario: It should subtract a negative number
+    Given the program
+      """
+      (- 5 -3 (- 1.5))
+      """
+    Then the result is
+      """
+      9.5
+      """
+
+  Scenario: It should compare integers and floats
+    Given the program
+      """
+      (list (< 1 1.5 2) (>= 2.0 2) (= 2 2.0) (not= 1 1.1))
+      """
+    Then the result is
+      """
+      true true true true
+      """
+
+  Scenario: It should convert numbers
+    Given the program
+      """
+      (list (int 3.9) (int -3.9) (int "42") (float 3) (float "0.25"))
+      """
+    Then the result is
+      """
+      3 -3 42 3.0 0.25
+      """
+
+  Scenario: It should round numbers
+    Given the program
+      """
+      (list (round 2.5) (round -2.5) (floor -1.5) (ceil 1.2) (round 7))
+      """
+    Then the result is
+      """
+      3 -3 -2 2 7
+      """
+
+  Scenario: It should not convert a string which isn't a number
+    Given the program
+      """
+      (try (int "abc") (catch e (error-message e)))
+      """
+    Then the result is
+      """
+      Cannot convert "abc" to INTEGER type.
+      """
+
+  Scenario: It should read negative numbers as arguments
+    Given the program
+      """
+      (list (- 3 -1) (+ -0.5 1) (* 2 -1/2))
+      """
+    Then the result is
+      """
+      4 0.5 -1
+      """
+
+  Scenario: It should subtract when a minus is at the start of an expression
+    Given the program
+      """
+      (-3 4)
+      """
+    Then the result is
+      """
+      -1
+      """
@@ -0,0 +1,77 @@
+Feature: Anonymous functions
+  Scenario: It should evaluate to an anonymous function
+    Given the program
+      """
+      (fn [x] (* x x))
+      """
+    Then the result is
+      """
+      (fn x)
+      """
+
+  Scenario: It should pass an anonymous function as an argument
+    Given the program
+      """
+      (let map [arg func] (func arg))
+      (map 10 (fn [a] (* a 2)))
+      """
+    Then the result is
+      """
+      20
+      """
+
+  Scenario: It should assign an anonymous function to an identifier
+    Given the program
+      """
+      (let add (fn [a b] (+ a b)))
+      (add 2 3)
+      """
+    Then the result is
+      """
+      5
+      """
+
+  Scenario: It should support an anonymous function without parameters
+    Given the program
+      """
+      (let answer (fn [] 42))
+      (answer)
+      """
+    Then the result is
+      """
+      42
+      """
+
+  Scenario: It should not bind anything in the environment
+    Given the program
+      """
+      (let apply [func] (func 1))
+      (apply (fn [a] a))
+      (_)
+      """
+    Then the result is
+      """
+      Function _ is undefined
+      """
+
+  Scenario: It should capture the environment in which it was created
+    Given the program
+      """
+      (let make-adder [n] (fn [x] (+ x n)))
+      (let add2 (make-adder 2))
+      (add2 3)
+      """
+    Then the result is
+      """
+      5
+      """
+
+  Scenario: It should not evaluate if parameters are missing
+    Given the program
+      """
+      (fn (+ 1 2))
+      """
+    Then the error is
+      """
+      1:2: 'fn' should be followed by a list of parameters.
+      """
@@ -0,0 +1,198 @@
+Feature: Higher-order builtins
+  Scenario: It should map a function over a list
+    Given the program
+      """
+      (repr (map (fn [x] (* x 2)) (list 1 2 3)))
+      """
+    Then the result is
+      """
+      (list 2 4 6)
+      """
+
+  Scenario: It should map a function over several lists
+    Given the program
+      """
+      (let add [a b] (+ a b))
+      (repr (map add (list 1 2 3) (list 10 20)))
+      """
+    Then the result is
+      """
+      (list 11 22)
+      """
+
+  Scenario: It should map a builtin and a keyword
+    Given the program
+      """
+      (repr (list (map size (list "a" "bc")) (map :name (list {:name "Ana"} {}))))
+      """
+    Then the result is
+      """
+      (list (list 1 2) (list "Ana" nil))
+      """
+
+  Scenario: It should map a function over characters of a string and pairs of a map
+    Given the program
+      """
+      (repr (list (map (fn [c] (+ c c)) "ab") (map (fn [pair] (last pair)) {:a 1 :b 2})))
+      """
+    Then the result is
+      """
+      (list (list "aa" "bb") (list 1 2))
+      """
+
+  Scenario: It should filter a list
+    Given the program
+      """
+      (repr (filter (fn [x] (> x 1)) (list 1 2 3)))
+      """
+    Then the result is
+      """
+      (list 2 3)
+      """
+
+  Scenario: It should require a predicate to evaluate to a boolean
+    Given the program
+      """
+      (filter (fn [x] x) (list 1 2 3))
+      """
+    Then the result is
+      """
+      Predicate should evaluate to BOOLEAN type. Found INTEGER type.
+      """
+
+  Scenario: It should reduce a list
+    Given the program
+      """
+      (let add [a b] (+ a b))
+      (repr (list (reduce add (list 1 2 3)) (reduce add 10 (list 1 2 3)) (reduce add nil)))
+      """
+    Then the result is
+      """
+      (list 6 16 nil)
+      """
+
+  Scenario: It should reduce a list with an initial value of a different type
+    Given the program
+      """
+      (repr (reduce (fn [acc x] (cons x acc)) nil (list 1 2 3)))
+      """
+    Then the result is
+      """
+      (list 3 2 1)
+      """
+
+  Scenario: It should check whether some or every element satisfies a predicate
+    Given the program
+      """
+      (let positive? [x] (> x 0))
+      (list (some positive? (list -1 2)) (some positive? nil) (every? positive? (list 1 2)) (every? positive? (list 1 -2)))
+      """
+    Then the result is
+      """
+      true false true false
+      """
+
+  Scenario: It should sort a list
+    Given the program
+      """
+      (repr (list (sort (list 3 1/2 2.5)) (sort (list "b" "c" "a")) (sort (list (list 2) (list 1 2)))))
+      """
+    Then the result is
+      """
+      (list (list 1/2 2.5 3) (list "a" "b" "c") (list (list 1 2) (list 2)))
+      """
+
+  Scenario: It should not sort values which cannot be compared
+    Given the program
+      """
+      (sort (list 1 "a"))
+      """
+    Then the result is
+      """
+      Values of types STRING and INTEGER cannot be compared.
+      """
+
+  Scenario: It should sort a list by keys and keep the order of equal keys
+    Given the program
+      """
+      (repr (sort-by (fn [p] (:age p)) (list {:name "a" :age 3} {:name "b" :age 1} {:name "c" :age 3})))
+      """
+    Then the result is
+      """
+      (list {:name "b" :age 1} {:name "a" :age 3} {:name "c" :age 3})
+      """
+
+  Scenario: It should group elements of a list
+    Given the program
+      """
+      (repr (group-by (fn [x] (% x 3)) (range 7)))
+      """
+    Then the result is
+      """
+      {0 (list 0 3 6) 1 (list 1 4) 2 (list 2 5)}
+      """
+
+  Scenario: It should apply a function to a list of arguments
+    Given the program
+      """
+      (let add [a b c] (+ a b c))
+      (list (apply add 1 (list 2 3)) (apply add (list 1 2 3)))
+      """
+    Then the result is
+      """
+      6 6
+      """
+
+  Scenario: It should report a wrong number of arguments of an applied function
+    Given the program
+      """
+      (let add [a b] (+ a b))
+      (map add (list 1 2))
+      """
+    Then the result is
+      """
+      Insufficient number of arguments. Expected 2, got 1.
+      """
+
+  Scenario: It should report too many arguments of an applied function
+    Given the program
+      """
+      (apply (fn [x] x) (list 1 2))
+      """
+    Then the result is
+      """
+      Too many arguments. Expected 1, got 2.
+      """
+
+  Scenario: It should not apply a value which isn't a function
+    Given the program
+      """
+      (map 1 (list 1 2))
+      """
+    Then the result is
+      """
+      Value of INTEGER type is not callable.
+      """
+
+  Scenario: It should record the call of a builtin which applied a failing function
+    Given the program
+      """
+      (let first-char [x] (head x))
+      (map first-char (list "a" 1))
+      """
+    Then the backtrace is
+      """
+      Error: Function is not applicable for INTEGER type.
+          at first-char (1:21)
+          at 2:1
+      """
+
+  Scenario: It should map a list with the deprecated order of arguments
+    Given the program
+      """
+      (list (map (list 1 2 3) (fn [x] (* x 2))) (map (list {:a 1}) :a))
+      """
+    Then the result is
+      """
+      2 4 6 1
+      """
@@ -97,7 +97,7 @@ Feature: If expression
       """
     Then the error is
       """
-      If expression is missing condition.
+      1:2: If expression is missing condition.
       """
 
   Scenario: It should not evaluate if "then" expression is missing
@@ -107,5 +107,5 @@ Feature: If expression
       """
     Then the error is
       """
-      If expression is missing then expression.
+      1:2: If expression is missing then expression.
       """
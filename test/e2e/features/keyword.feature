@@ -0,0 +1,94 @@
+Feature: Keywords
+  Scenario: It should evaluate keywords
+    Given the program
+      """
+      (list :name :is-valid? :v2)
+      """
+    Then the result is
+      """
+      :name :is-valid? :v2
+      """
+
+  Scenario: It should compare keywords
+    Given the program
+      """
+      (let status :ok)
+      (list (= status :ok) (= status :error) (not= status :error) (= :ok :ok :ok))
+      """
+    Then the result is
+      """
+      true false true true
+      """
+
+  Scenario: It should not treat keywords as equal to strings
+    Given the program
+      """
+      (list (= :ok "ok") (= "ok" :ok) (= :ok nil))
+      """
+    Then the result is
+      """
+      false false false
+      """
+
+  Scenario: It should use keywords as map keys
+    Given the program
+      """
+      (let person {:name "Ana" :age 30})
+      (list person (get person :age))
+      """
+    Then the result is
+      """
+      {:name "Ana" :age 30} 30
+      """
+
+  Scenario: It should look a keyword up in a map
+    Given the program
+      """
+      (let person {:name "Ana"})
+      (list (:name person) (:age person) (:age person 0))
+      """
+    Then the result is
+      """
+      Ana nil 0
+      """
+
+  Scenario: It should look a keyword up in a map returned by a function
+    Given the program
+      """
+      (let make-person [name] {:name name})
+      (:name (make-person "Ana"))
+      """
+    Then the result is
+      """
+      Ana
+      """
+
+  Scenario: It should not look a keyword up in a value which isn't a map
+    Given the program
+      """
+      (:name "Ana")
+      """
+    Then the result is
+      """
+      Function is not applicable for STRING type.
+      """
+
+  Scenario: It should not allow a colon without a name
+    Given the program
+      """
+      (list : 1)
+      """
+    Then the error is
+      """
+      1:7: Illegal character ':' found.
+      """
+
+  Scenario: It should allow keywords named like operators
+    Given the program
+      """
+      (repr (list :if :fn :list :not (get {:let 1} :let)))
+      """
+    Then the result is
+      """
+      (list :if :fn :list :not 1)
+      """
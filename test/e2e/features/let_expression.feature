@@ -175,5 +175,5 @@ Feature: Let expression
       """
     Then the error is
       """
-      Illegal use of operator 'if' at index 11.
+      1:11: Illegal use of operator 'if'.
       """
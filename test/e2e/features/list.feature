@@ -93,7 +93,7 @@ Feature: List evaluation
   Scenario: It should evaluate a builtin function 'size' for a list
     Given the program
       """
-      (size (list (1 2 3 4)))
+      (size (list 1 2 3 4))
       """
     Then the result is
       """
@@ -0,0 +1,183 @@
+Feature: List builtins
+  Scenario: It should give nil for the head and the tail of nil
+    Given the program
+      """
+      (list (head nil) (tail nil) (head (tail (list 1))))
+      """
+    Then the result is
+      """
+      nil nil nil
+      """
+
+  Scenario: It should put a value in front of a list
+    Given the program
+      """
+      (repr (list (cons 1 (list 2 3)) (cons (list 1) nil)))
+      """
+    Then the result is
+      """
+      (list (list 1 2 3) (list (list 1)))
+      """
+
+  Scenario: It should append values to a new list
+    Given the program
+      """
+      (let lst (list 1 2))
+      (repr (list (append lst 3 4) lst (append nil 1)))
+      """
+    Then the result is
+      """
+      (list (list 1 2 3 4) (list 1 2) (list 1))
+      """
+
+  Scenario: It should concatenate lists
+    Given the program
+      """
+      (repr (list (concat (list 1 2) nil (list 3)) (concat nil nil)))
+      """
+    Then the result is
+      """
+      (list (list 1 2 3) nil)
+      """
+
+  Scenario: It should give an element at an index
+    Given the program
+      """
+      (list (nth (list "a" "b" "c") 0) (nth (list "a" "b" "c") 2) (nth "hello" 1))
+      """
+    Then the result is
+      """
+      a c e
+      """
+
+  Scenario: It should not give an element at an index out of range
+    Given the program
+      """
+      (nth (list 1 2 3) 3)
+      """
+    Then the result is
+      """
+      Index 3 is out of range for size 3.
+      """
+
+  Scenario: It should not give an element at a negative index
+    Given the program
+      """
+      (let lst (list 1 2 3))
+      (try (nth lst -1) (catch e (error-kind e)))
+      """
+    Then the result is
+      """
+      INDEX_ERROR
+      """
+
+  Scenario: It should not give an element at an index which isn't an integer
+    Given the program
+      """
+      (nth (list 1 2 3) 1.0)
+      """
+    Then the result is
+      """
+      Index must be of INTEGER type, got FLOAT.
+      """
+
+  Scenario: It should give the last element
+    Given the program
+      """
+      (list (last (list 1 2 3)) (last nil) (last "abc"))
+      """
+    Then the result is
+      """
+      3 nil c
+      """
+
+  Scenario: It should reverse a list and a string
+    Given the program
+      """
+      (repr (list (reverse (list 1 2 3)) (reverse "abc") (reverse nil)))
+      """
+    Then the result is
+      """
+      (list (list 3 2 1) "cba" nil)
+      """
+
+  Scenario: It should give a range of integers
+    Given the program
+      """
+      (repr (list (range 4) (range 2 5) (range 10 0 -3) (range 5 1)))
+      """
+    Then the result is
+      """
+      (list (list 0 1 2 3) (list 2 3 4) (list 10 7 4 1) nil)
+      """
+
+  Scenario: It should not give a range with a zero step
+    Given the program
+      """
+      (range 0 10 0)
+      """
+    Then the result is
+      """
+      Step of a range cannot be zero.
+      """
+
+  Scenario: It should take and drop elements of a list
+    Given the program
+      """
+      (let lst (list 1 2 3))
+      (repr (list (take 2 lst) (drop 2 lst) (take 5 lst) (drop 5 lst) (take -1 lst)))
+      """
+    Then the result is
+      """
+      (list (list 1 2) (list 3) (list 1 2 3) nil nil)
+      """
+
+  Scenario: It should take and drop characters of a string
+    Given the program
+      """
+      (repr (list (take 2 "hello") (drop 2 "hello")))
+      """
+    Then the result is
+      """
+      (list "he" "llo")
+      """
+
+  Scenario: It should check whether a collection is empty
+    Given the program
+      """
+      (list (empty? nil) (empty? (list 1)) (empty? "") (empty? "a") (empty? {}) (empty? #{1}))
+      """
+    Then the result is
+      """
+      true false true false true false
+      """
+
+  Scenario: It should flatten nested lists
+    Given the program
+      """
+      (repr (flatten (list 1 (list 2 (list 3 (list 4))) nil 5)))
+      """
+    Then the result is
+      """
+      (list 1 2 3 4 nil 5)
+      """
+
+  Scenario: It should not apply a list builtin to a value which isn't a list
+    Given the program
+      """
+      (cons 1 2)
+      """
+    Then the result is
+      """
+      Function is not applicable for INTEGER type.
+      """
+
+  Scenario: It should not create a range which is too large
+    Given the program
+      """
+      (range 0 10000000000)
+      """
+    Then the result is
+      """
+      Range of 10000000000 values is too large. It can have at most 4194304 values.
+      """
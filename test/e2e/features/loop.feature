@@ -0,0 +1,148 @@
+Feature: Loop
+  Scenario: It should calculate a factorial with loop and recur
+    Given the program
+      """
+      (loop [i 1 acc 1]
+        (if (> i 5)
+          acc
+          (recur (+ i 1) (* acc i))))
+      """
+    Then the result is
+      """
+      120
+      """
+
+  Scenario: It should iterate a million times
+    Given the program
+      """
+      (loop [i 0]
+        (if (< i 1000000)
+          (recur (+ i 1))
+          i))
+      """
+    Then the result is
+      """
+      1000000
+      """
+
+  Scenario: It should allow bindings to refer to previous bindings
+    Given the program
+      """
+      (loop [a 2 b (* a 3)] (+ a b))
+      """
+    Then the result is
+      """
+      8
+      """
+
+  Scenario: It should use function parameters within a loop
+    Given the program
+      """
+      (let sum-to [n]
+        (loop [i 0 acc 0]
+          (if (> i n)
+            acc
+            (recur (+ i 1) (+ acc i)))))
+      (sum-to 100)
+      """
+    Then the result is
+      """
+      5050
+      """
+
+  Scenario: It should support nested loops
+    Given the program
+      """
+      (loop [i 0 acc 0]
+        (if (= i 3)
+          acc
+          (recur (+ i 1) (+ acc (loop [j 0 sum 0] (if (= j 4) sum (recur (+ j 1) (+ sum 1))))))))
+      """
+    Then the result is
+      """
+      12
+      """
+
+  Scenario: It should evaluate a loop without bindings
+    Given the program
+      """
+      (loop [] 7)
+      """
+    Then the result is
+      """
+      7
+      """
+
+  Scenario: It should return an error when "recur" has too few arguments
+    Given the program
+      """
+      (loop [i 0 acc 0]
+        (if (> i 3) acc (recur (+ i 1))))
+      """
+    Then the result is
+      """
+      Insufficient number of arguments. Expected 2, got 1.
+      """
+
+  Scenario: It should return an error when "recur" has too many arguments
+    Given the program
+      """
+      (loop [i 0]
+        (if (> i 3) i (recur (+ i 1) 2)))
+      """
+    Then the result is
+      """
+      Too many arguments. Expected 1, got 2.
+      """
+
+  Scenario: It should not allow "recur" outside of a tail position
+    Given the program
+      """
+      (loop [i 0]
+        (if (> i 3) i (+ 1 (recur (+ i 1)))))
+      """
+    Then the error is
+      """
+      2:23: 'recur' can only be used in a tail position of 'loop'.
+      """
+
+  Scenario: It should not allow "recur" in a condition of "if"
+    Given the program
+      """
+      (loop [i 0]
+        (if (recur 1) i 2))
+      """
+    Then the error is
+      """
+      2:8: 'recur' can only be used in a tail position of 'loop'.
+      """
+
+  Scenario: It should not allow "recur" outside of a loop
+    Given the program
+      """
+      (let f [x] (recur x))
+      """
+    Then the error is
+      """
+      1:13: 'recur' can only be used in a tail position of 'loop'.
+      """
+
+  Scenario: It should not allow "recur" within a function inside of a loop
+    Given the program
+      """
+      (loop [i 0] (fn [x] (recur x)))
+      """
+    Then the error is
+      """
+      1:22: 'recur' can only be used in a tail position of 'loop'.
+      """
+
+  Scenario: It should not allow a binding without an initial value
+    Given the program
+      """
+      (loop [i] i)
+      """
+    Then the error is
+      """
+      1:8: Missing an initial value for 'i'.
+      """
@@ -0,0 +1,168 @@
+Feature: Maps
+  Scenario: It should evaluate a map literal
+    Given the program
+      """
+      (let m {"name" "Bell" 1 (list 2 3) true nil nil false})
+      (list m)
+      """
+    Then the result is
+      """
+      {"name" "Bell" 1 (list 2 3) true nil nil false}
+      """
+
+  Scenario: It should evaluate an empty map literal
+    Given the program
+      """
+      ({})
+      """
+    Then the result is
+      """
+      {}
+      """
+
+  Scenario: It should evaluate keys and values of a map literal
+    Given the program
+      """
+      (let x 2)
+      (let m {(+ x 1) (* x 3)})
+      (get m 3)
+      """
+    Then the result is
+      """
+      6
+      """
+
+  Scenario: It should keep the last value of a repeated key
+    Given the program
+      """
+      ({"a" 1 "b" 2 "a" 3})
+      """
+    Then the result is
+      """
+      {"a" 3 "b" 2}
+      """
+
+  Scenario: It should get a value of a key
+    Given the program
+      """
+      (let m {"a" 1 2 "b"})
+      (list (get m "a") (get m 2) (get m "c") (get m "c" 0))
+      """
+    Then the result is
+      """
+      1 b nil 0
+      """
+
+  Scenario: It should treat equal integers as the same key
+    Given the program
+      """
+      (get {9223372036854775808 "big"} (+ 9223372036854775807 1))
+      """
+    Then the result is
+      """
+      big
+      """
+
+  Scenario: It should associate keys with values in a new map
+    Given the program
+      """
+      (let m {"a" 1})
+      (list (assoc m "a" 2 "b" 3) m)
+      """
+    Then the result is
+      """
+      {"a" 2 "b" 3} {"a" 1}
+      """
+
+  Scenario: It should remove keys from a new map
+    Given the program
+      """
+      (let m {"a" 1 "b" 2 "c" 3})
+      (list (dissoc m "a" "c") m)
+      """
+    Then the result is
+      """
+      {"b" 2} {"a" 1 "b" 2 "c" 3}
+      """
+
+  Scenario: It should give keys and values in the order of insertion
+    Given the program
+      """
+      (let m (assoc {"b" 1 "a" 2} "c" 3))
+      (list (keys m) (vals m))
+      """
+    Then the result is
+      """
+      b a c 1 2 3
+      """
+
+  Scenario: It should give nil for keys of an empty map
+    Given the program
+      """
+      (keys {})
+      """
+    Then the result is
+      """
+      nil
+      """
+
+  Scenario: It should check whether a map contains a key
+    Given the program
+      """
+      (let m {"a" nil})
+      (list (contains? m "a") (contains? m "b"))
+      """
+    Then the result is
+      """
+      true false
+      """
+
+  Scenario: It should give the size of a map
+    Given the program
+      """
+      (list (size {}) (size {"a" 1 "b" 2}))
+      """
+    Then the result is
+      """
+      0 2
+      """
+
+  Scenario: It should treat nil as an empty map
+    Given the program
+      """
+      (list (get nil "a") (assoc nil "a" 1))
+      """
+    Then the result is
+      """
+      nil {"a" 1}
+      """
+
+  Scenario: It should not allow a list as a key
+    Given the program
+      """
+      ({(list 1) 2})
+      """
+    Then the result is
+      """
+      Value of LIST type cannot be used as a map key or a set element.
+      """
+
+  Scenario: It should not allow a key without a value
+    Given the program
+      """
+      (assoc {} "a" 1 "b")
+      """
+    Then the result is
+      """
+      Keys must be followed by values.
+      """
+
+  Scenario: It should not allow a map literal without a value
+    Given the program
+      """
+      (let m {"a"})
+      """
+    Then the error is
+      """
+      1:8: Map literal must contain an even number of expressions.
+      """
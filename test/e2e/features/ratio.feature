@@ -0,0 +1,120 @@
+Feature: Exact rational numbers
+  Scenario: It should evaluate ratio literals in the lowest terms
+    Given the program
+      """
+      (list 3/4 6/8 -1/2 4/2)
+      """
+    Then the result is
+      """
+      3/4 3/4 -1/2 2
+      """
+
+  Scenario: It should divide integers exactly
+    Given the program
+      """
+      (list (ratio 7 2) (ratio 6 3) (ratio 1 2 3))
+      """
+    Then the result is
+      """
+      7/2 2 1/6
+      """
+
+  Scenario: It should add ratios
+    Given the program
+      """
+      (+ 1/3 1/6)
+      """
+    Then the result is
+      """
+      1/2
+      """
+
+  Scenario: It should give an integer when a result has no fraction
+    Given the program
+      """
+      (+ 1/3 2/3 1)
+      """
+    Then the result is
+      """
+      2
+      """
+
+  Scenario: It should mix ratios and integers
+    Given the program
+      """
+      (list (* 3/4 2) (- 1 1/4) (/ 7/2 2))
+      """
+    Then the result is
+      """
+      3/2 3/4 7/4
+      """
+
+  Scenario: It should promote ratios to floats
+    Given the program
+      """
+      (+ 1/4 0.5)
+      """
+    Then the result is
+      """
+      0.75
+      """
+
+  Scenario: It should raise a ratio to a power
+    Given the program
+      """
+      (list (^ 2/3 2) (^ 2/3 -2) (^ 1/4 1/2))
+      """
+    Then the result is
+      """
+      4/9 9/4 0.5
+      """
+
+  Scenario: It should give a remainder of ratios
+    Given the program
+      """
+      (list (% 7/2 1) (% -7/2 1))
+      """
+    Then the result is
+      """
+      1/2 -1/2
+      """
+
+  Scenario: It should compare ratios
+    Given the program
+      """
+      (list (< 1/3 1/2 1) (= 2/4 1/2 0.5) (> 1/3 0.3))
+      """
+    Then the result is
+      """
+      true true true
+      """
+
+  Scenario: It should give a numerator and a denominator
+    Given the program
+      """
+      (list (numerator 6/8) (denominator 6/8) (numerator 5) (denominator 5))
+      """
+    Then the result is
+      """
+      3 4 5 1
+      """
+
+  Scenario: It should convert and round ratios
+    Given the program
+      """
+      (list (int 7/2) (float 1/4) (round 5/2) (round -5/2) (floor -7/2) (ceil 7/2))
+      """
+    Then the result is
+      """
+      3 0.25 3 -3 -4 4
+      """
+
+  Scenario: It should not divide floats exactly
+    Given the program
+      """
+      (try (ratio 1.5 2) (catch e (error-message e)))
+      """
+    Then the result is
+      """
+      Function is not applicable for FLOAT type.
+      """
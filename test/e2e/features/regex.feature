@@ -0,0 +1,100 @@
+Feature: Regular expressions
+  Scenario: It should write a regex in its literal form
+    Given the program
+      """
+      (repr (list #"\d+" (re "say \"hi\"") (= #"a+" (re "a+"))))
+      """
+    Then the result is
+      """
+      (list #"\d+" #"say \"hi\"" true)
+      """
+
+  Scenario: It should check whether a regex matches a string
+    Given the program
+      """
+      (list (re-match? #"\d+" "abc 12") (re-match? #"^\d+$" "abc 12") (re-match? "b" "abc"))
+      """
+    Then the result is
+      """
+      true false true
+      """
+
+  Scenario: It should find the first match
+    Given the program
+      """
+      (repr (list (re-find #"\d+" "a 12 b 3") (re-find #"\d+" "abc")))
+      """
+    Then the result is
+      """
+      (list "12" nil)
+      """
+
+  Scenario: It should give capture groups as a list
+    Given the program
+      """
+      (repr (re-find #"(\w+)@(\w+)(\.org)?" "mail bob@example now"))
+      """
+    Then the result is
+      """
+      (list "bob@example" "bob" "example" nil)
+      """
+
+  Scenario: It should find all matches
+    Given the program
+      """
+      (repr (list (re-find-all #"(\w)=(\d)" "a=1, b=2") (re-find-all #"\d" "abc")))
+      """
+    Then the result is
+      """
+      (list (list (list "a=1" "a" "1") (list "b=2" "b" "2")) nil)
+      """
+
+  Scenario: It should replace matches with a string
+    Given the program
+      """
+      (re-replace #"(\w+)@(\w+)" "bob@example, al@test" "$2:$1")
+      """
+    Then the result is
+      """
+      example:bob, test:al
+      """
+
+  Scenario: It should replace matches with results of a function
+    Given the program
+      """
+      (re-replace #"\d+" "a 1 b 22" (fn [m] (* 2 (int m))))
+      """
+    Then the result is
+      """
+      a 2 b 44
+      """
+
+  Scenario: It should split a string around matches
+    Given the program
+      """
+      (repr (re-split #"\s*,\s*" "a , b,c"))
+      """
+    Then the result is
+      """
+      (list "a" "b" "c")
+      """
+
+  Scenario: It should not compile an invalid regex
+    Given the program
+      """
+      (re "(a")
+      """
+    Then the result is
+      """
+      Invalid regular expression: missing closing ): `(a`.
+      """
+
+  Scenario: It should report an invalid regex literal
+    Given the program
+      """
+      (re-find #"(a" "a")
+      """
+    Then the error is
+      """
+      1:10: Invalid regular expression: missing closing ): `(a`.
+      """
@@ -0,0 +1,60 @@
+Feature: Readable representation
+  Scenario: It should represent strings with quotes and escapes
+    Given the program
+      """
+      (repr "say \"hi\"\n")
+      """
+    Then the result is
+      """
+      "say \"hi\"\n"
+      """
+
+  Scenario: It should represent lists which differ only in spaces within strings
+    Given the program
+      """
+      (list (repr (list "a b" "c")) (repr (list "a" "b" "c")))
+      """
+    Then the result is
+      """
+      (list "a b" "c") (list "a" "b" "c")
+      """
+
+  Scenario: It should represent nested lists
+    Given the program
+      """
+      (repr (list 1 (list 2 3) (list (list 4))))
+      """
+    Then the result is
+      """
+      (list 1 (list 2 3) (list (list 4)))
+      """
+
+  Scenario: It should represent numbers, keywords, booleans and nil
+    Given the program
+      """
+      (repr (list -1 3/4 2.0 :ok true nil))
+      """
+    Then the result is
+      """
+      (list -1 3/4 2.0 :ok true nil)
+      """
+
+  Scenario: It should represent maps and sets in their literal form
+    Given the program
+      """
+      (repr {"a" (list 1 2) :b #{"c"}})
+      """
+    Then the result is
+      """
+      {"a" (list 1 2) :b #{"c"}}
+      """
+
+  Scenario: It should display strings and lists without quotes and parentheses
+    Given the program
+      """
+      (+ "" (list "a b" "c"))
+      """
+    Then the result is
+      """
+      a b c
+      """
@@ -0,0 +1,124 @@
+Feature: Sets
+  Scenario: It should evaluate a set literal without duplicates
+    Given the program
+      """
+      (let s #{1 "a" :b 1 "a" nil true})
+      (list s)
+      """
+    Then the result is
+      """
+      #{1 "a" :b nil true}
+      """
+
+  Scenario: It should evaluate an empty set literal
+    Given the program
+      """
+      (#{})
+      """
+    Then the result is
+      """
+      #{}
+      """
+
+  Scenario: It should convert a list to a set
+    Given the program
+      """
+      (set (list 3 1 3 2 1))
+      """
+    Then the result is
+      """
+      #{3 1 2}
+      """
+
+  Scenario: It should check whether a set contains a value
+    Given the program
+      """
+      (let s #{1 :a})
+      (list (contains? s 1) (contains? s :a) (contains? s 2))
+      """
+    Then the result is
+      """
+      true true false
+      """
+
+  Scenario: It should add values to a new set
+    Given the program
+      """
+      (let s #{1 2})
+      (list (conj s 2 3 4) s)
+      """
+    Then the result is
+      """
+      #{1 2 3 4} #{1 2}
+      """
+
+  Scenario: It should remove values from a new set
+    Given the program
+      """
+      (let s #{1 2 3})
+      (list (disj s 1 3 5) s)
+      """
+    Then the result is
+      """
+      #{2} #{1 2 3}
+      """
+
+  Scenario: It should give a union of sets
+    Given the program
+      """
+      (union #{1 2} #{2 3} #{4})
+      """
+    Then the result is
+      """
+      #{1 2 3 4}
+      """
+
+  Scenario: It should give an intersection of sets
+    Given the program
+      """
+      (intersection #{1 2 3 4} #{4 3 2} #{2 4})
+      """
+    Then the result is
+      """
+      #{2 4}
+      """
+
+  Scenario: It should give a difference of sets
+    Given the program
+      """
+      (difference #{1 2 3 4} #{2} #{4 5})
+      """
+    Then the result is
+      """
+      #{1 3}
+      """
+
+  Scenario: It should give the size of a set
+    Given the program
+      """
+      (list (size #{}) (size #{1 1 2}))
+      """
+    Then the result is
+      """
+      0 2
+      """
+
+  Scenario: It should not allow a list as a set element
+    Given the program
+      """
+      (conj #{} (list 1))
+      """
+    Then the result is
+      """
+      Value of LIST type cannot be used as a map key or a set element.
+      """
+
+  Scenario: It should not combine a set with a list
+    Given the program
+      """
+      (union #{1} (list 2))
+      """
+    Then the result is
+      """
+      Function is not applicable for LIST type.
+      """
@@ -0,0 +1,150 @@
+Feature: String builtins
+  Scenario: It should count characters of a string
+    Given the program
+      """
+      (list (size "žuta") (size ""))
+      """
+    Then the result is
+      """
+      4 0
+      """
+
+  Scenario: It should split a string
+    Given the program
+      """
+      (repr (list (split "a,b,,c" ",") (split "žut" "")))
+      """
+    Then the result is
+      """
+      (list (list "a" "b" "" "c") (list "ž" "u" "t"))
+      """
+
+  Scenario: It should join elements of a list
+    Given the program
+      """
+      (repr (list (join (list "a" 1 :b) "-") (join (list "x" "y")) (join nil ",")))
+      """
+    Then the result is
+      """
+      (list "a-1-:b" "xy" "")
+      """
+
+  Scenario: It should give a substring
+    Given the program
+      """
+      (repr (list (substring "žuta" 1) (substring "žuta" 1 3) (substring "žuta" 4)))
+      """
+    Then the result is
+      """
+      (list "uta" "ut" "")
+      """
+
+  Scenario: It should not give a substring out of range
+    Given the program
+      """
+      (substring "abc" 1 4)
+      """
+    Then the result is
+      """
+      Index 4 is out of range for size 3.
+      """
+
+  Scenario: It should not give a substring which ends before it starts
+    Given the program
+      """
+      (substring "abc" 2 1)
+      """
+    Then the result is
+      """
+      Start 2 is greater than end 1.
+      """
+
+  Scenario: It should give an index of a substring in characters
+    Given the program
+      """
+      (list (index-of "čćžabc" "ab") (index-of "abc" "z"))
+      """
+    Then the result is
+      """
+      3 -1
+      """
+
+  Scenario: It should replace all occurrences of a substring
+    Given the program
+      """
+      (replace "a-b-c" "-" "+")
+      """
+    Then the result is
+      """
+      a+b+c
+      """
+
+  Scenario: It should change the case of a string
+    Given the program
+      """
+      (list (upper "žut") (lower "ŽUT"))
+      """
+    Then the result is
+      """
+      ŽUT žut
+      """
+
+  Scenario: It should trim whitespace
+    Given the program
+      """
+      (repr (trim "  text \n"))
+      """
+    Then the result is
+      """
+      "text"
+      """
+
+  Scenario: It should check the start, the end and the contents of a string
+    Given the program
+      """
+      (list (starts-with? "hello" "he") (ends-with? "hello" "he") (contains? "hello" "ell") (contains? "hello" "z"))
+      """
+    Then the result is
+      """
+      true false true false
+      """
+
+  Scenario: It should repeat a string
+    Given the program
+      """
+      (repr (list (repeat "ab" 3) (repeat "ab" 0)))
+      """
+    Then the result is
+      """
+      (list "ababab" "")
+      """
+
+  Scenario: It should give characters of a string
+    Given the program
+      """
+      (repr (list (chars "žu") (chars "")))
+      """
+    Then the result is
+      """
+      (list (list "ž" "u") nil)
+      """
+
+  Scenario: It should not apply a string builtin to a value which isn't a string
+    Given the program
+      """
+      (upper 1)
+      """
+    Then the result is
+      """
+      Function is not applicable for INTEGER type.
+      """
+
+  Scenario: It should not repeat a string into one which is too large
+    Given the program
+      """
+      (repeat "ab" 10000000000)
+      """
+    Then the result is
+      """
+      A repeated string is too large. It can have at most 67108864 bytes.
+      """
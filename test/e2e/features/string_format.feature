@@ -0,0 +1,126 @@
+Feature: String formatting and interpolation
+  Scenario: It should format values with verbs
+    Given the program
+      """
+      (format "%s: %d, %.2f %q 100%%" "Sum" 42 1/3 "hi")
+      """
+    Then the result is
+      """
+      Sum: 42, 0.33 "hi" 100%
+      """
+
+  Scenario: It should format values with a width and flags
+    Given the program
+      """
+      (format "|%5d|%-4s|%05.1f|%x|" 42 "ab" 2.25 255)
+      """
+    Then the result is
+      """
+      |   42|ab  |002.2|ff|
+      """
+
+  Scenario: It should format big integers and collections
+    Given the program
+      """
+      (format "%d %s %q" 123456789012345678901234567890 (list 1 2) {:a "b"})
+      """
+    Then the result is
+      """
+      123456789012345678901234567890 1 2 {:a "b"}
+      """
+
+  Scenario: It should not format a float as an integer
+    Given the program
+      """
+      (format "%d" 1.5)
+      """
+    Then the result is
+      """
+      Verb '%d' cannot format a value of FLOAT type.
+      """
+
+  Scenario: It should not format without enough values
+    Given the program
+      """
+      (format "%s and %s" "a")
+      """
+    Then the result is
+      """
+      Missing a value for verb '%s'.
+      """
+
+  Scenario: It should not format with too many values
+    Given the program
+      """
+      (format "%s" "a" "b")
+      """
+    Then the result is
+      """
+      Too many values for the format string. Expected 1, got 2.
+      """
+
+  Scenario: It should not format with an unknown verb
+    Given the program
+      """
+      (format "%y" 1)
+      """
+    Then the result is
+      """
+      Unknown verb '%y'.
+      """
+
+  Scenario: It should interpolate expressions into a string
+    Given the program
+      """
+      (let name "Bell")
+      (let x 2)
+      (repr "Hello ${name}! ${x} + ${x} = ${(+ x x)}")
+      """
+    Then the result is
+      """
+      "Hello Bell! 2 + 2 = 4"
+      """
+
+  Scenario: It should interpolate values in their display form
+    Given the program
+      """
+      (repr (list "${1}${2}" "${(list 1 "a")}" "${{:a "b"}}" "${"in ${nil}"}"))
+      """
+    Then the result is
+      """
+      (list "12" "1 a" "{:a \"b\"}" "in nil")
+      """
+
+  Scenario: It should not interpolate an escaped expression
+    Given the program
+      """
+      (list "\${x}" (repr "\${x}"))
+      """
+    Then the result is
+      """
+      ${x} "\${x}"
+      """
+
+  Scenario: It should report an empty interpolation
+    Given the program
+      """
+      (writeln "Sum: ${}")
+      """
+    Then the error is
+      """
+      1:18: Missing an expression within '${}'.
+      """
+
+  Scenario: It should point to an interpolated expression which caused a runtime error
+    Given the program
+      """
+      (writeln "Head: ${(head 1)}")
+      """
+    Then the diagnostic is
+      """
+      error: Function is not applicable for INTEGER type.
+       --> 1:19
+        |
+      1 | (writeln "Head: ${(head 1)}")
+        |                   ^
+      """
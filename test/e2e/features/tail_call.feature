@@ -0,0 +1,62 @@
+Feature: Tail calls
+  Scenario: It should recurse a million times in a tail position
+    Given the program
+      """
+      (let count [n acc]
+        (if (= n 0)
+          acc
+          (count (- n 1) (+ acc 1))))
+      (count 1000000 0)
+      """
+    Then the result is
+      """
+      1000000
+      """
+
+  Scenario: It should recurse in a tail position of the "then" branch
+    Given the program
+      """
+      (let count [n acc]
+        (if (> n 0)
+          (count (- n 1) (+ acc 2))
+          acc))
+      (count 1000000 0)
+      """
+    Then the result is
+      """
+      2000000
+      """
+
+  Scenario: It should support mutual recursion in a tail position
+    Given the program
+      """
+      (let even? [n] (if (= n 0) true (odd? (- n 1))))
+      (let odd? [n] (if (= n 0) false (even? (- n 1))))
+      (even? 100001)
+      """
+    Then the result is
+      """
+      false
+      """
+
+  Scenario: It should evaluate a tail call of an anonymous function
+    Given the program
+      """
+      (let bounce [n] (if (= n 0) "done" ((fn [x] (bounce x)) (- n 1))))
+      (bounce 100000)
+      """
+    Then the result is
+      """
+      done
+      """
+
+  Scenario: It should still evaluate a recursion which is not in a tail position
+    Given the program
+      """
+      (let factorial [n] (if (= n 0) 1 (* n (factorial (- n 1)))))
+      (factorial 10)
+      """
+    Then the result is
+      """
+      3628800
+      """
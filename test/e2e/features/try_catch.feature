@@ -0,0 +1,177 @@
+Feature: Error handling
+  Scenario: It should evaluate the body of "try" when there is no error
+    Given the program
+      """
+      (try (+ 1 2) (catch e 0))
+      """
+    Then the result is
+      """
+      3
+      """
+
+  Scenario: It should catch a thrown string
+    Given the program
+      """
+      (try (throw "Invalid input") (catch e (error-message e)))
+      """
+    Then the result is
+      """
+      Invalid input
+      """
+
+  Scenario: It should give a kind of a thrown error
+    Given the program
+      """
+      (try (throw "Invalid input") (catch e (error-kind e)))
+      """
+    Then the result is
+      """
+      THROWN_ERROR
+      """
+
+  Scenario: It should catch any thrown value
+    Given the program
+      """
+      (try (throw (list 1 2)) (catch e (head (tail (error-value e)))))
+      """
+    Then the result is
+      """
+      2
+      """
+
+  Scenario: It should catch an error produced by an operator
+    Given the program
+      """
+      (try (+ 2 false) (catch e (error-kind e)))
+      """
+    Then the result is
+      """
+      TYPE_ERROR
+      """
+
+  Scenario: It should catch an error produced by a builtin function
+    Given the program
+      """
+      (try (head 2) (catch e (error-message e)))
+      """
+    Then the result is
+      """
+      Function is not applicable for INTEGER type.
+      """
+
+  Scenario: It should catch an error of a wrong number of arguments
+    Given the program
+      """
+      (let sq [x] (* x x))
+      (try (sq 1 2) (catch e (error-kind e)))
+      """
+    Then the result is
+      """
+      ARITY_ERROR
+      """
+
+  Scenario: It should catch an error thrown from a nested function call
+    Given the program
+      """
+      (let validate [x] (if (< x 0) (throw "Negative number") x))
+      (let double [x] (* 2 (validate x)))
+      (try (double (- 3)) (catch e (+ "Error: " (error-message e))))
+      """
+    Then the result is
+      """
+      Error: Negative number
+      """
+
+  Scenario: It should fall back to a default value
+    Given the program
+      """
+      (let parse-or-default [x default]
+        (try (+ x 1) (catch e default)))
+      (parse-or-default true 0)
+      """
+    Then the result is
+      """
+      0
+      """
+
+  Scenario: It should stop the evaluation of the body after an error
+    Given the program
+      """
+      (try (throw "first") (throw "second") (catch e (error-message e)))
+      """
+    Then the result is
+      """
+      first
+      """
+
+  Scenario: It should rethrow a caught error
+    Given the program
+      """
+      (try
+        (try (head 1) (catch e (throw e)))
+        (catch outer (error-kind outer)))
+      """
+    Then the result is
+      """
+      TYPE_ERROR
+      """
+
+  Scenario: It should give an error when an uncaught value is thrown
+    Given the program
+      """
+      (throw "Failure")
+      """
+    Then the result is
+      """
+      Failure
+      """
+
+  Scenario: It should not allow "try" without "catch"
+    Given the program
+      """
+      (try (+ 1 2))
+      """
+    Then the error is
+      """
+      1:2: 'try' should end with a 'catch' clause.
+      """
+
+  Scenario: It should not allow "catch" outside of "try"
+    Given the program
+      """
+      (catch e 1)
+      """
+    Then the error is
+      """
+      1:2: 'catch' can only be used as the last expression of 'try'.
+      """
+
+  Scenario: It should not allow "catch" before the last expression of "try"
+    Given the program
+      """
+      (try (catch e 1) (+ 1 2) (catch e 2))
+      """
+    Then the error is
+      """
+      1:7: 'catch' can only be used as the last expression of 'try'.
+      """
+
+  Scenario: It should report too many arguments of "throw"
+    Given the program
+      """
+      (throw 1 2)
+      """
+    Then the result is
+      """
+      Too many arguments. Expected 1, got 2.
+      """
+
+  Scenario: It should report a missing argument of "error-message"
+    Given the program
+      """
+      (error-message)
+      """
+    Then the result is
+      """
+      Insufficient number of arguments. Expected 1, got 0.
+      """
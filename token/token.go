@@ -1,28 +1,66 @@
 package token
 
+import "fmt"
+
 type TokType string
 
 type Token struct {
 	Type    TokType
 	Literal string
+	Pos     Position
+}
+
+// Position of a token within a source file.
+// Lines and columns start from 1.
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+func (p Position) String() string {
+	if p.File == "" {
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
 }
 
 const (
 	StartExpression = "START_EXPRESSION"
 	EndExpression   = "END_EXPRESSION"
 	INT             = "INT"
+	FLOAT           = "FLOAT"
+	RATIO           = "RATIO"
 	BOOL            = "BOOL"
 	LET             = "LET"
+	FN              = "FN"
 	IF              = "IF"
+	LOOP            = "LOOP"
+	RECUR           = "RECUR"
+	TRY             = "TRY"
+	CATCH           = "CATCH"
 	LIST            = "LIST"
 	STRING          = "STRING"
+	StringStart     = "STRING_START"  // Part of a string before the first interpolation
+	StringMiddle    = "STRING_MIDDLE" // Part of a string between two interpolations
+	StringEnd       = "STRING_END"    // Part of a string after the last interpolation
+	KEYWORD         = "KEYWORD"
+	REGEX           = "REGEX"
 	OPEN            = "OPEN"
 	IDENT           = "IDENT"
 	NIL             = "NIL"
 	StartParamList  = "START_PARAM_LIST"
 	EndParamList    = "END_PARAM_LIST"
+	StartMap        = "START_MAP"
+	EndMap          = "END_MAP"
+	StartSet        = "START_SET" // Sets are closed with EndMap as well
 	EOF             = "EOF"
 	EOL             = "EOL"
+	COMMENT         = "COMMENT"
 	ILLEGAL         = "ILLEGAL"
 	// Operators
 	ADD              = "ADD"
@@ -50,7 +88,12 @@ var keywords = map[string]TokType{
 	"not":   NOT,
 	"not=":  NotEqual,
 	"let":   LET,
+	"fn":    FN,
 	"if":    IF,
+	"loop":  LOOP,
+	"recur": RECUR,
+	"try":   TRY,
+	"catch": CATCH,
 	"list":  LIST,
 	"open":  OPEN,
 	"nil":   NIL,
@@ -70,4 +113,6 @@ var OperatorLiterals = []string{
 	"or", ">", ">=",
 	"<", "<=", "not",
 	"list", "if",
-	"^", "open"}
+	"^", "open", "fn",
+	"loop", "recur", "try",
+	"catch"}